@@ -23,7 +23,7 @@ public class BukanirClient {
     public static ArrayList<Movie> getTopResults(int category, int limit, int refresh, String cacheDir, int cacheDays, String tpbHost) {
         String result = null;
         try {
-            result = Bukanir.category(category, limit, refresh, cacheDir, cacheDays, tpbHost);
+            result = Bukanir.category(category, limit, refresh, cacheDir, cacheDays, tpbHost, "");
         } catch(Exception e) {
             e.printStackTrace();
         }
@@ -44,7 +44,7 @@ public class BukanirClient {
     public static ArrayList<Movie> getSearchResults(String query, int limit, int refresh, String cacheDir, int cacheDays, int pages, String tpbHost, String eztvHost) {
         String result = null;
         try {
-            result = Bukanir.search(query, limit, refresh, cacheDir, cacheDays, pages, tpbHost, eztvHost, "all");
+            result = Bukanir.search(query, limit, refresh, cacheDir, cacheDays, pages, tpbHost, eztvHost, "", "all", "");
         } catch(Exception e) {
             e.printStackTrace();
         }
@@ -128,7 +128,7 @@ public class BukanirClient {
     public static String getTrailer(String videoId) {
         String result = null;
         try {
-            result = Bukanir.trailer(videoId);
+            result = Bukanir.trailer(videoId, "360p", Bukanir.TrailerDirect);
         } catch(Exception e) {
             e.printStackTrace();
         }
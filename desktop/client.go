@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"log"
 
 	"github.com/gen2brain/bukanir/lib"
@@ -9,16 +12,23 @@ import (
 // Client type
 type Client struct {
 	CacheDir string
+	Lib      *bukanir.Client
+}
+
+// Completion type, search query completions with people
+type Completion struct {
+	Items  []bukanir.TItem   `json:"items"`
+	People []bukanir.TPerson `json:"people"`
 }
 
 // NewClient returns new Client
 func NewClient() *Client {
-	return &Client{cacheDir()}
+	return &Client{cacheDir(), bukanir.NewClient()}
 }
 
 // Top movies
-func (c *Client) Top(widget *List, category, limit, force, cacheDays int, tpbHost string) {
-	data, err := bukanir.Category(category, limit, force, c.CacheDir, int64(cacheDays), tpbHost)
+func (c *Client) Top(handle *bukanir.Handle, widget *List, category, limit, force, cacheDays int, tpbHost, filter string) {
+	data, err := handle.Category(category, limit, force, c.CacheDir, int64(cacheDays), tpbHost, filter)
 	if err != nil {
 		log.Printf("ERROR: Category: %s\n", err.Error())
 		widget.Finished("")
@@ -29,8 +39,8 @@ func (c *Client) Top(widget *List, category, limit, force, cacheDays int, tpbHos
 }
 
 // Search movies
-func (c *Client) Search(widget *List, query string, limit, force, cacheDays, pages int, tpbHost, eztvHost, sortBy, media string) {
-	data, err := bukanir.Search(query, limit, force, c.CacheDir, int64(cacheDays), pages, tpbHost, eztvHost, sortBy, media)
+func (c *Client) Search(handle *bukanir.Handle, widget *List, query string, limit, force, cacheDays, pages int, tpbHost, eztvHost, sortBy, media, filter string) {
+	data, err := handle.Search(query, limit, force, c.CacheDir, int64(cacheDays), pages, tpbHost, eztvHost, sortBy, media, filter)
 	if err != nil {
 		log.Printf("ERROR: Search: %s\n", err.Error())
 		widget.Finished("")
@@ -42,8 +52,8 @@ func (c *Client) Search(widget *List, query string, limit, force, cacheDays, pag
 }
 
 // Summary for movie
-func (c *Client) Summary(widget *Summary, m bukanir.TMovie) {
-	data, err := bukanir.Summary(m.Id, m.Category, m.Season, m.Episode)
+func (c *Client) Summary(handle *bukanir.Handle, widget *Summary, m bukanir.TMovie) {
+	data, err := handle.Summary(m.Id, m.Category, m.Season, m.Episode)
 	if err != nil {
 		log.Printf("ERROR: Summary: %s\n", err.Error())
 		widget.Finished("")
@@ -53,16 +63,33 @@ func (c *Client) Summary(widget *Summary, m bukanir.TMovie) {
 	widget.Finished(data)
 }
 
-// Complete search query
+// Complete search query, people are suggested with titles
 func (c *Client) Complete(widget *Toolbar, text string) {
-	data, err := bukanir.AutoComplete(text, 10)
-	if err != nil {
+	ctx := context.Background()
+
+	items, err := c.Lib.AutoComplete(ctx, text, 10)
+	if err != nil && !errors.Is(err, bukanir.ErrNoResults) {
 		log.Printf("ERROR: AutoComplete: %s\n", err.Error())
+	}
+
+	people, err := c.Lib.SearchPerson(ctx, text, 5)
+	if err != nil && !errors.Is(err, bukanir.ErrNoResults) {
+		log.Printf("ERROR: SearchPerson: %s\n", err.Error())
+	}
+
+	if len(items)+len(people) == 0 {
 		widget.Finished("")
 		return
 	}
 
-	widget.Finished(data)
+	data, err := json.Marshal(Completion{items, people})
+	if err != nil {
+		log.Printf("ERROR: Marshal: %s\n", err.Error())
+		widget.Finished("")
+		return
+	}
+
+	widget.Finished(string(data))
 }
 
 // Popular movies
@@ -102,8 +129,8 @@ func (c *Client) Genres(widget *Toolbar) {
 }
 
 // Genre movies
-func (c *Client) Genre(widget *List, id int, limit int, force int, cacheDays int, tpbHost string) {
-	data, err := bukanir.Genre(id, limit, force, c.CacheDir, int64(cacheDays), tpbHost)
+func (c *Client) Genre(handle *bukanir.Handle, widget *List, id int, limit int, force int, cacheDays int, tpbHost string, filter string) {
+	data, err := handle.Genre(id, limit, force, c.CacheDir, int64(cacheDays), tpbHost, filter)
 	if err != nil {
 		log.Printf("ERROR: Genre: %s\n", err.Error())
 		widget.Finished("")
@@ -114,8 +141,8 @@ func (c *Client) Genre(widget *List, id int, limit int, force int, cacheDays int
 }
 
 // Cast movies
-func (c *Client) Cast(widget *List, id int, limit int, force int, cacheDays int, tpbHost string) {
-	data, err := bukanir.Cast(id, limit, force, c.CacheDir, int64(cacheDays), tpbHost)
+func (c *Client) Cast(handle *bukanir.Handle, widget *List, id int, limit int, force int, cacheDays int, tpbHost string) {
+	data, err := handle.Cast(id, limit, force, c.CacheDir, int64(cacheDays), tpbHost)
 	if err != nil {
 		log.Printf("ERROR: Genre: %s\n", err.Error())
 		widget.Finished("")
@@ -126,8 +153,8 @@ func (c *Client) Cast(widget *List, id int, limit int, force int, cacheDays int,
 }
 
 // Crew movies
-func (c *Client) Crew(widget *List, id int, limit int, force int, cacheDays int, tpbHost string) {
-	data, err := bukanir.Crew(id, limit, force, c.CacheDir, int64(cacheDays), tpbHost)
+func (c *Client) Crew(handle *bukanir.Handle, widget *List, id int, limit int, force int, cacheDays int, tpbHost string) {
+	data, err := handle.Crew(id, limit, force, c.CacheDir, int64(cacheDays), tpbHost)
 	if err != nil {
 		log.Printf("ERROR: Genre: %s\n", err.Error())
 		widget.Finished("")
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/therecipe/qt/core"
+	"github.com/therecipe/qt/widgets"
+
+	"github.com/gen2brain/bukanir/lib"
+)
+
+// Filter sources
+var filterSources = []string{"CAM", "TS", "TC", "SCR", "WEB-DL", "WEBRip", "BluRay", "HDTV", "DVDRip", "HDRip"}
+
+// Filter codecs
+var filterCodecs = []string{"x264", "x265", "AV1", "VP9", "XviD"}
+
+// Filter sizes in MiB
+var filterSizes = []int64{0, 100, 500, 1024, 2048, 5120, 10240, 20480, 51200}
+
+// Filter type
+type Filter struct {
+	*widgets.QDialog
+	*core.QSettings
+
+	comboMinResolution *widgets.QComboBox
+	comboMaxResolution *widgets.QComboBox
+	comboMinSize       *widgets.QComboBox
+	comboMaxSize       *widgets.QComboBox
+	spinSeeders        *widgets.QSpinBox
+	checkSources       []*widgets.QCheckBox
+	checkCodecs        []*widgets.QCheckBox
+	lineRequired       *widgets.QLineEdit
+	lineExcluded       *widgets.QLineEdit
+	buttonBox          *widgets.QDialogButtonBox
+}
+
+// NewFilter returns new filter
+func NewFilter(parent *widgets.QWidget) *Filter {
+	widget := widgets.NewQDialog(parent, 0)
+	widget.SetWindowTitle(tr("Filter"))
+	widget.Resize2(430, 480)
+
+	resolutions := []string{tr("Any"), "480p", "720p", "1080p", "2160p"}
+
+	sizes := make([]string, 0)
+	for _, s := range filterSizes {
+		sizes = append(sizes, sizeText(s))
+	}
+
+	// Quality
+	groupQuality := widgets.NewQGroupBox2(tr("Quality"), widget)
+
+	labelMinResolution := widgets.NewQLabel2(tr("Minimum resolution"), widget, 0)
+	labelMaxResolution := widgets.NewQLabel2(tr("Maximum resolution"), widget, 0)
+
+	comboMinResolution := widgets.NewQComboBox(widget)
+	comboMinResolution.AddItems(resolutions)
+
+	comboMaxResolution := widgets.NewQComboBox(widget)
+	comboMaxResolution.AddItems(resolutions)
+
+	qualityLayout := widgets.NewQGridLayout2()
+	qualityLayout.AddWidget(labelMinResolution, 0, 0, 0)
+	qualityLayout.AddWidget(comboMinResolution, 0, 1, 0)
+	qualityLayout.AddWidget(labelMaxResolution, 1, 0, 0)
+	qualityLayout.AddWidget(comboMaxResolution, 1, 1, 0)
+
+	groupQuality.SetLayout(qualityLayout)
+
+	// Sources
+	groupSources := widgets.NewQGroupBox2(tr("Sources"), widget)
+	groupSources.SetToolTip(tr("Releases without recognized source are always shown"))
+
+	sourcesLayout := widgets.NewQGridLayout2()
+	checkSources := make([]*widgets.QCheckBox, 0)
+	for n, s := range filterSources {
+		check := widgets.NewQCheckBox2(s, widget)
+		sourcesLayout.AddWidget(check, n/5, n%5, 0)
+		checkSources = append(checkSources, check)
+	}
+
+	groupSources.SetLayout(sourcesLayout)
+
+	// Codecs
+	groupCodecs := widgets.NewQGroupBox2(tr("Codecs"), widget)
+	groupCodecs.SetToolTip(tr("Releases without recognized codec are always shown"))
+
+	codecsLayout := widgets.NewQGridLayout2()
+	checkCodecs := make([]*widgets.QCheckBox, 0)
+	for n, c := range filterCodecs {
+		check := widgets.NewQCheckBox2(c, widget)
+		codecsLayout.AddWidget(check, 0, n, 0)
+		checkCodecs = append(checkCodecs, check)
+	}
+
+	groupCodecs.SetLayout(codecsLayout)
+
+	// Torrents
+	groupTorrents := widgets.NewQGroupBox2(tr("Torrents"), widget)
+
+	labelMinSize := widgets.NewQLabel2(tr("Minimum size"), widget, 0)
+	labelMaxSize := widgets.NewQLabel2(tr("Maximum size"), widget, 0)
+	labelSeeders := widgets.NewQLabel2(tr("Minimum seeders"), widget, 0)
+	labelRequired := widgets.NewQLabel2(tr("Required words"), widget, 0)
+	labelExcluded := widgets.NewQLabel2(tr("Excluded words"), widget, 0)
+
+	comboMinSize := widgets.NewQComboBox(widget)
+	comboMinSize.AddItems(sizes)
+
+	comboMaxSize := widgets.NewQComboBox(widget)
+	comboMaxSize.AddItems(sizes)
+
+	spinSeeders := widgets.NewQSpinBox(widget)
+	spinSeeders.SetMinimum(0)
+	spinSeeders.SetMaximum(10000)
+
+	lineRequired := widgets.NewQLineEdit(widget)
+	lineRequired.SetToolTip(tr("Comma separated words that must be in release name"))
+
+	lineExcluded := widgets.NewQLineEdit(widget)
+	lineExcluded.SetToolTip(tr("Comma separated words that must not be in release name"))
+
+	torrentsLayout := widgets.NewQGridLayout2()
+	torrentsLayout.AddWidget(labelMinSize, 0, 0, 0)
+	torrentsLayout.AddWidget(comboMinSize, 0, 1, 0)
+	torrentsLayout.AddWidget(labelMaxSize, 1, 0, 0)
+	torrentsLayout.AddWidget(comboMaxSize, 1, 1, 0)
+	torrentsLayout.AddWidget(labelSeeders, 2, 0, 0)
+	torrentsLayout.AddWidget(spinSeeders, 2, 1, 0)
+	torrentsLayout.AddWidget(labelRequired, 3, 0, 0)
+	torrentsLayout.AddWidget(lineRequired, 3, 1, 0)
+	torrentsLayout.AddWidget(labelExcluded, 4, 0, 0)
+	torrentsLayout.AddWidget(lineExcluded, 4, 1, 0)
+
+	groupTorrents.SetLayout(torrentsLayout)
+
+	// Close
+	buttonBox := widgets.NewQDialogButtonBox3(widgets.QDialogButtonBox__Close|widgets.QDialogButtonBox__RestoreDefaults, widget)
+	buttonBox.Button(widgets.QDialogButtonBox__Close).SetText(tr("Close"))
+	buttonBox.Button(widgets.QDialogButtonBox__RestoreDefaults).SetText(tr("Restore Defaults"))
+
+	// Layout
+	layout := widgets.NewQVBoxLayout()
+	layout.AddWidget(groupQuality, 0, 0)
+	layout.AddWidget(groupSources, 0, 0)
+	layout.AddWidget(groupCodecs, 0, 0)
+	layout.AddWidget(groupTorrents, 0, 0)
+	layout.AddWidget(buttonBox, 0, 0)
+	widget.SetLayout(layout)
+
+	qsettings := core.NewQSettings4(filepath.Join(configDir(), "bukanir.conf"), core.QSettings__IniFormat, parent)
+
+	filter := &Filter{
+		widget, qsettings,
+		comboMinResolution, comboMaxResolution, comboMinSize, comboMaxSize, spinSeeders,
+		checkSources, checkCodecs, lineRequired, lineExcluded, buttonBox,
+	}
+
+	filter.Set()
+
+	return filter
+}
+
+// ConnectSignals connects signals
+func (f *Filter) ConnectSignals() {
+	f.buttonBox.Button(widgets.QDialogButtonBox__RestoreDefaults).ConnectClicked(func(bool) {
+		f.setFilter(defaultFilter())
+	})
+
+	f.buttonBox.ConnectRejected(func() {
+		f.Save()
+		f.Sync()
+		f.QDialog.Close()
+	})
+}
+
+// Set sets values
+func (f *Filter) Set() {
+	filter := defaultFilter()
+
+	value := f.Value("filter", core.NewQVariant14("")).ToString()
+	if value != "" {
+		err := json.Unmarshal([]byte(value), &filter)
+		if err != nil {
+			log.Printf("ERROR: Unmarshal: %s\n", err.Error())
+		}
+	}
+
+	f.setFilter(filter)
+}
+
+// Save saves values
+func (f *Filter) Save() {
+	f.SetValue("filter", core.NewQVariant14(f.JSON()))
+}
+
+// JSON returns filter as json string, empty string for default filter
+func (f *Filter) JSON() string {
+	filter := f.filter()
+
+	js, err := json.Marshal(filter)
+	if err != nil {
+		log.Printf("ERROR: Marshal: %s\n", err.Error())
+		return ""
+	}
+
+	def, _ := json.Marshal(defaultFilter())
+	if string(js) == string(def) {
+		return ""
+	}
+
+	return string(js[:])
+}
+
+// filter returns filter from widgets
+func (f *Filter) filter() bukanir.TFilter {
+	var filter bukanir.TFilter
+
+	filter.MinResolution = resolutionValue(f.comboMinResolution.CurrentText())
+	filter.MaxResolution = resolutionValue(f.comboMaxResolution.CurrentText())
+	filter.MinSize = filterSizes[f.comboMinSize.CurrentIndex()] * 1024 * 1024
+	filter.MaxSize = filterSizes[f.comboMaxSize.CurrentIndex()] * 1024 * 1024
+	filter.MinSeeders = f.spinSeeders.Value()
+
+	for n, c := range f.checkSources {
+		if c.IsChecked() {
+			filter.Sources = append(filter.Sources, filterSources[n])
+		}
+	}
+	if len(filter.Sources) == len(filterSources) {
+		filter.Sources = nil
+	}
+
+	for n, c := range f.checkCodecs {
+		if c.IsChecked() {
+			filter.Codecs = append(filter.Codecs, filterCodecs[n])
+		}
+	}
+	if len(filter.Codecs) == len(filterCodecs) {
+		filter.Codecs = nil
+	}
+
+	filter.Required = splitWords(f.lineRequired.Text())
+	filter.Excluded = splitWords(f.lineExcluded.Text())
+
+	return filter
+}
+
+// setFilter sets widgets from filter
+func (f *Filter) setFilter(filter bukanir.TFilter) {
+	setResolution := func(combo *widgets.QComboBox, value int) {
+		combo.SetCurrentIndex(0)
+		if value > 0 {
+			combo.SetCurrentText(strconv.Itoa(value) + "p")
+		}
+	}
+
+	setSize := func(combo *widgets.QComboBox, value int64) {
+		combo.SetCurrentIndex(0)
+		for n, s := range filterSizes {
+			if s*1024*1024 == value {
+				combo.SetCurrentIndex(n)
+			}
+		}
+	}
+
+	setResolution(f.comboMinResolution, filter.MinResolution)
+	setResolution(f.comboMaxResolution, filter.MaxResolution)
+	setSize(f.comboMinSize, filter.MinSize)
+	setSize(f.comboMaxSize, filter.MaxSize)
+	f.spinSeeders.SetValue(filter.MinSeeders)
+
+	for n, c := range f.checkSources {
+		c.SetChecked(len(filter.Sources) == 0 || inSlice(filterSources[n], filter.Sources))
+	}
+
+	for n, c := range f.checkCodecs {
+		c.SetChecked(len(filter.Codecs) == 0 || inSlice(filterCodecs[n], filter.Codecs))
+	}
+
+	f.lineRequired.SetText(strings.Join(filter.Required, ", "))
+	f.lineExcluded.SetText(strings.Join(filter.Excluded, ", "))
+}
+
+// defaultFilter returns default filter, same as the one used by library
+func defaultFilter() bukanir.TFilter {
+	return bukanir.TFilter{MaxSize: 5120 * 1024 * 1024, MinSeeders: 1}
+}
+
+// resolutionValue returns resolution from combo text
+func resolutionValue(text string) int {
+	value, _ := strconv.Atoi(strings.TrimSuffix(text, "p"))
+	return value
+}
+
+// sizeText returns human size for size in MiB
+func sizeText(size int64) string {
+	if size == 0 {
+		return tr("Any")
+	} else if size < 1024 {
+		return strconv.FormatInt(size, 10) + " MiB"
+	}
+	return strconv.FormatInt(size/1024, 10) + " GiB"
+}
+
+// splitWords splits comma separated words
+func splitWords(text string) []string {
+	words := make([]string, 0)
+	for _, w := range strings.Split(text, ",") {
+		w = strings.TrimSpace(w)
+		if w != "" {
+			words = append(words, w)
+		}
+	}
+
+	if len(words) == 0 {
+		return nil
+	}
+
+	return words
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/postfinance/single"
 	"github.com/therecipe/qt/core"
@@ -72,9 +73,40 @@ func main() {
 	}
 
 	bukanir.SetVerbose(true)
+	bukanir.SetMetadataDir(filepath.Join(cacheDir(), "tmdb"))
+	bukanir.SetImdbDir(filepath.Join(cacheDir(), "imdb"))
+	bukanir.SetImageCache(filepath.Join(cacheDir(), "images"), 200)
+	bukanir.SetTrailerCache(filepath.Join(cacheDir(), "trailers"), 500)
+
+	_, err = bukanir.StartImageServer("127.0.0.1:0")
+	if err != nil {
+		log.Printf("ERROR: StartImageServer: %s\n", err.Error())
+	}
+
+	err = bukanir.LoadCredentials(filepath.Join(configDir(), "credentials.json"))
+	if err != nil && !os.IsNotExist(err) {
+		log.Printf("ERROR: LoadCredentials: %s\n", err.Error())
+	}
+
+	go func() {
+		err := bukanir.ValidateCredentials()
+		if err != nil {
+			log.Printf("ERROR: ValidateCredentials: %s\n", err.Error())
+		}
+	}()
+
+	go func() {
+		err := bukanir.UpdateImdbRatings(false)
+		if err != nil {
+			log.Printf("ERROR: UpdateImdbRatings: %s\n", err.Error())
+		}
+	}()
 	defer bukanir.TorStop()
 
 	locale := core.NewQLocale().System().Name()
+	if parts := strings.SplitN(locale, "_", 2); len(parts) == 2 {
+		bukanir.SetMetadataLanguage(parts[0], parts[1])
+	}
 	translator := core.NewQTranslator(app)
 	translator.Load(":qml/i18n/bukanir."+locale+".qm", ":/qml/i18n", "", "")
 	app.InstallTranslator(translator)
@@ -1,7 +1,8 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -155,39 +156,34 @@ func (p *Player) Wait(movie bukanir.TMovie, imdbId string) (bool, string) {
 			retry += 1
 		}
 
-		s, err := bukanir.TorrentStatus()
-		if err == nil {
-			var status bukanir.TStatus
-			err = json.Unmarshal([]byte(s), &status)
-
-			if err == nil && status.State != -1 {
-				if status.State == 3 {
-					d := fmt.Sprintf("D:%.2fkB/s U:%.2fkB/s S:%d (%d) P:%d (%d)",
-						status.DownloadRate, status.UploadRate, status.NumSeeds, status.TotalSeeds, status.NumPeers, status.TotalPeers)
-					p.Window.LabelStatus.ValueChanged(status.StateStr + "... " + d)
-				} else {
-					p.Window.LabelStatus.ValueChanged(status.StateStr + "...")
-				}
+		status := bukanir.TorrentStatusInfo()
+		if status.State != -1 {
+			if status.State == 3 {
+				d := fmt.Sprintf("D:%.2fkB/s U:%.2fkB/s S:%d (%d) P:%d (%d)",
+					status.DownloadRate, status.UploadRate, status.NumSeeds, status.TotalSeeds, status.NumPeers, status.TotalPeers)
+				p.Window.LabelStatus.ValueChanged(status.StateStr + "... " + d)
+			} else {
+				p.Window.LabelStatus.ValueChanged(status.StateStr + "...")
+			}
 
-				if status.State >= 3 && !ready {
-					f := bukanir.TorrentLargestFile()
-					err = json.Unmarshal([]byte(f), &file)
-					if err != nil {
-						continue
-					}
+			if status.State >= 3 && !ready {
+				f, err := bukanir.TorrentLargestFileInfo()
+				if err != nil {
+					continue
+				}
+				file = f
 
-					required := file.Size / 100
-					value := float64(status.TotalDownload) / float64(required) * 100
-					p.Window.ProgressBar.ValueChanged(int(value))
+				required := file.Size / 100
+				value := float64(status.TotalDownload) / float64(required) * 100
+				p.Window.ProgressBar.ValueChanged(int(value))
 
-					if status.TotalDownload >= required {
-						p.Window.ProgressBar.SetVisible(false)
-						p.Window.LabelStatus.ValueChanged("")
+				if status.TotalDownload >= required {
+					p.Window.ProgressBar.SetVisible(false)
+					p.Window.LabelStatus.ValueChanged("")
 
-						if subsReady {
-							ready = true
-							break
-						}
+					if subsReady {
+						ready = true
+						break
 					}
 				}
 			}
@@ -202,20 +198,16 @@ func (p *Player) Wait(movie bukanir.TMovie, imdbId string) (bool, string) {
 // Status shows torrent status on pause
 func (p *Player) Status() {
 	for p.IsPaused() {
-		s, err := bukanir.TorrentStatus()
-		if err == nil {
-			var status bukanir.TStatus
-			err = json.Unmarshal([]byte(s), &status)
-			if err == nil {
-				if status.State == 3 {
-					progress := fmt.Sprintf("%s... (%.2f%%)", status.StateStr, status.Progress*100)
-					d := fmt.Sprintf(" D:%.2fkB/s U:%.2fkB/s S:%d (%d) P:%d (%d)",
-						status.DownloadRate, status.UploadRate, status.NumSeeds, status.TotalSeeds, status.NumPeers, status.TotalPeers)
-					p.Window.LabelStatus.ValueChanged(progress + d)
-				} else {
-					state := fmt.Sprintf("%s...", status.StateStr)
-					p.Window.LabelStatus.ValueChanged(state)
-				}
+		status := bukanir.TorrentStatusInfo()
+		if status.State != -1 {
+			if status.State == 3 {
+				progress := fmt.Sprintf("%s... (%.2f%%)", status.StateStr, status.Progress*100)
+				d := fmt.Sprintf(" D:%.2fkB/s U:%.2fkB/s S:%d (%d) P:%d (%d)",
+					status.DownloadRate, status.UploadRate, status.NumSeeds, status.TotalSeeds, status.NumPeers, status.TotalPeers)
+				p.Window.LabelStatus.ValueChanged(progress + d)
+			} else {
+				state := fmt.Sprintf("%s...", status.StateStr)
+				p.Window.LabelStatus.ValueChanged(state)
 			}
 		}
 
@@ -227,41 +219,32 @@ func (p *Player) Status() {
 
 // AddSubtitles add subtitles to player
 func (p *Player) AddSubtitles(m bukanir.TMovie, imdbId string, subDir string) {
-	str, err := bukanir.Subtitle(m.Title, m.Year, m.Release, p.Window.Settings.Language, m.Category, m.Season, m.Episode, imdbId)
-	if err != nil {
-		log.Printf("ERROR: Subtitle: %s\n", err.Error())
-		return
-	}
+	ctx := context.Background()
 
-	var subs []bukanir.TSubtitle
-	err = json.Unmarshal([]byte(str), &subs)
+	subs, err := p.Window.Client.Lib.Subtitle(ctx, m.Title, m.Year, m.Release, p.Window.Settings.Language, m.Category, m.Season, m.Episode, imdbId)
 	if err != nil {
-		log.Printf("ERROR: Unmarshal: %s\n", err.Error())
+		if !errors.Is(err, bukanir.ErrNoResults) {
+			log.Printf("ERROR: Subtitle: %s\n", err.Error())
+		}
 		return
 	}
 
 	cnt := len(subs)
-
-	if cnt == 0 {
-		return
-	}
-
 	if cnt >= 5 {
 		cnt = 5
 	}
 
 	list := make([]*mpv.Node, 0)
 	for _, sub := range subs[:cnt] {
-		subPath, err := bukanir.UnzipSubtitle(sub.DownloadLink, subDir)
-
+		subPath, err := p.Window.Client.Lib.UnzipSubtitle(ctx, sub.DownloadLink, subDir)
 		if err != nil {
-			log.Printf("ERROR: UnzipSubtitle: %s\n", err.Error())
+			if !errors.Is(err, bukanir.ErrNoResults) {
+				log.Printf("ERROR: UnzipSubtitle: %s\n", err.Error())
+			}
 			continue
 		}
 
-		if subPath != "" && subPath != "empty" {
-			list = append(list, &mpv.Node{Data: subPath, Format: mpv.FORMAT_STRING})
-		}
+		list = append(list, &mpv.Node{Data: subPath, Format: mpv.FORMAT_STRING})
 	}
 
 	node := &mpv.Node{Data: list, Format: mpv.FORMAT_NODE_ARRAY}
@@ -121,7 +121,7 @@ func (l *List) Init(manager *network.QNetworkAccessManager, data string) {
 		item := NewListItem(l, m)
 		l.InsertItem(idx, item)
 
-		reply := manager.Get(network.NewQNetworkRequest(core.NewQUrl3(m.PosterLarge, core.QUrl__TolerantMode)))
+		reply := manager.Get(network.NewQNetworkRequest(core.NewQUrl3(bukanir.LocalImage(m.PosterLarge), core.QUrl__TolerantMode)))
 		reply.ConnectFinished(func() {
 			defer reply.DeleteLater()
 
@@ -185,8 +185,9 @@ type Summary struct {
 	Cast     *widgets.QButtonGroup
 	Director *widgets.QPushButton
 
-	Video  string
-	ImdbId string
+	Video   string
+	ImdbId  string
+	Details bukanir.TSummary
 
 	Player *Player
 
@@ -406,7 +407,7 @@ func NewSummary(parent *widgets.QTabWidget) *Summary {
 	buttonDirector.SetVisible(false)
 
 	return &Summary{
-		NewObject(parent), frame, labelPoster, buttonWatch, buttonTrailer, buttonGroup, buttonDirector, "", "", nil, false, false,
+		NewObject(parent), frame, labelPoster, buttonWatch, buttonTrailer, buttonGroup, buttonDirector, "", "", bukanir.TSummary{}, nil, false, false,
 		layoutCast, labelCast, labelDirector, labelGenre, labelOverview, labelRatingYear, labelRuntimeSize,
 		labelRelease, labelTagline, labelTitle, labelTmdb, labelTmdbLogo, labelOpenSubs,
 	}
@@ -438,6 +439,7 @@ func (l *Summary) Init(m bukanir.TMovie, data string) {
 
 	l.Video = s.Video
 	l.ImdbId = s.ImdbId
+	l.Details = s
 
 	if len(s.CastIds) > 4 {
 		s.CastIds = s.CastIds[0:4]
@@ -545,11 +547,14 @@ type Toolbar struct {
 	Input    *widgets.QLineEdit
 	Media    *widgets.QToolButton
 	SortBy   *widgets.QToolButton
+	Filter   *widgets.QToolButton
 	Top      *widgets.QToolButton
 	Year     *widgets.QToolButton
 	Popular  *widgets.QToolButton
 	TopRated *widgets.QToolButton
 	Genre    *widgets.QToolButton
+
+	People map[string]bukanir.TPerson
 }
 
 // NewToolbar returns new toolbar
@@ -608,6 +613,11 @@ func NewToolbar(parent *widgets.QWidget) *Toolbar {
 	sortByButton.SetMinimumSize2(47, 27)
 	sortByButton.SetText(tr("Sort By"))
 
+	filterButton := widgets.NewQToolButton(widget)
+	filterButton.SetSizePolicy2(widgets.QSizePolicy__Fixed, widgets.QSizePolicy__Fixed)
+	filterButton.SetMinimumSize2(47, 27)
+	filterButton.SetText(tr("Filter"))
+
 	topButton := widgets.NewQToolButton(widget)
 	topButton.SetPopupMode(widgets.QToolButton__InstantPopup)
 	topButton.SetSizePolicy2(widgets.QSizePolicy__Fixed, widgets.QSizePolicy__Fixed)
@@ -668,10 +678,14 @@ func NewToolbar(parent *widgets.QWidget) *Toolbar {
 	act2 = widgets.NewQAction2(tr("Episodes"), widget)
 	act2.SetCheckable(true)
 
+	act3 = widgets.NewQAction2(tr("Score"), widget)
+	act3.SetCheckable(true)
+
 	sortByActionGroup.AddAction(act1)
 	sortByActionGroup.AddAction(act2)
+	sortByActionGroup.AddAction(act3)
 
-	sortByMenu.AddActions([]*widgets.QAction{act1, act2})
+	sortByMenu.AddActions([]*widgets.QAction{act1, act2, act3})
 	sortByButton.SetMenu(sortByMenu)
 
 	topMenu := widgets.NewQMenu(widget)
@@ -712,6 +726,7 @@ func NewToolbar(parent *widgets.QWidget) *Toolbar {
 	hlayout.AddWidget(refreshButton, 0, 0)
 	hlayout.AddWidget(mediaButton, 0, 0)
 	hlayout.AddWidget(sortByButton, 0, 0)
+	hlayout.AddWidget(filterButton, 0, 0)
 	hlayout.AddSpacerItem(widgets.NewQSpacerItem(30, 20, widgets.QSizePolicy__Fixed, widgets.QSizePolicy__Preferred))
 	hlayout.AddWidget(topButton, 0, 0)
 	hlayout.AddWidget(yearButton, 0, 0)
@@ -731,7 +746,8 @@ func NewToolbar(parent *widgets.QWidget) *Toolbar {
 	widget.SetLayout(layout)
 
 	toolbar := &Toolbar{NewObject(parent), widget, searchButton, refreshButton, logButton, settingsButton, aboutButton,
-		lineInput, mediaButton, sortByButton, topButton, yearButton, popularButton, topRatedButton, byGenreButton}
+		lineInput, mediaButton, sortByButton, filterButton, topButton, yearButton, popularButton, topRatedButton, byGenreButton,
+		make(map[string]bukanir.TPerson)}
 
 	toolbar.ConnectFinished2(func(data string) {
 		var d []bukanir.TItem
@@ -800,6 +816,7 @@ func (t *Toolbar) SetEnabled(enabled bool) {
 	t.Refresh.SetEnabled(enabled)
 	t.Media.SetEnabled(enabled)
 	t.SortBy.SetEnabled(enabled)
+	t.Filter.SetEnabled(enabled)
 	t.Top.SetEnabled(enabled)
 	t.Year.SetEnabled(enabled)
 	t.Popular.SetEnabled(enabled)
@@ -807,9 +824,9 @@ func (t *Toolbar) SetEnabled(enabled bool) {
 	t.Genre.SetEnabled(enabled)
 }
 
-// Complete completes search queries
+// Complete completes search queries, people are listed after titles with their department
 func (t *Toolbar) Complete(w *Window, data string) {
-	var c []bukanir.TItem
+	var c Completion
 	err := json.Unmarshal([]byte(data), &c)
 	if err != nil {
 		log.Printf("ERROR: Unmarshal: %s\n", err.Error())
@@ -817,12 +834,21 @@ func (t *Toolbar) Complete(w *Window, data string) {
 	}
 
 	list := make([]string, 0)
-	for _, a := range c {
+	for _, a := range c.Items {
 		if !inSlice(a.Title, list) {
 			list = append(list, a.Title)
 		}
 	}
 
+	t.People = make(map[string]bukanir.TPerson)
+	for _, p := range c.People {
+		text := fmt.Sprintf("%s (%s)", p.Name, p.Department)
+		if !inSlice(text, list) {
+			list = append(list, text)
+			t.People[text] = p
+		}
+	}
+
 	w.Model.SetStringList(list)
 	w.Completer.Complete(core.NewQRect())
 }
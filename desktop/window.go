@@ -33,6 +33,7 @@ type Tab struct {
 	Movie    bukanir.TMovie
 	Widget   *List
 	Widget2  *Summary
+	Handle   *bukanir.Handle
 }
 
 // Window type
@@ -42,6 +43,7 @@ type Window struct {
 	Log      *Log
 	Client   *Client
 	Settings *Settings
+	Filter   *Filter
 
 	Toolbar   *Toolbar
 	TabWidget *widgets.QTabWidget
@@ -72,10 +74,11 @@ func NewWindow() *Window {
 		side = widgets.QTabBar__RightSide
 	}
 
-	window := &Window{w, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, side, nil}
+	window := &Window{w, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, side, nil}
 	window.Log = NewLog(window.QWidget_PTR())
 	window.Client = NewClient()
 	window.Settings = NewSettings(window.QWidget_PTR())
+	window.Filter = NewFilter(window.QWidget_PTR())
 
 	window.Manager = network.NewQNetworkAccessManager(w)
 	cache := network.NewQNetworkDiskCache(w)
@@ -157,6 +160,7 @@ func (w *Window) AddWidgets() {
 // ConnectSignals connects signals
 func (w *Window) ConnectSignals() {
 	w.Settings.ConnectSignals()
+	w.Filter.ConnectSignals()
 
 	w.Log.ConnectValueChanged(func(value string) {
 		if strings.Contains(strings.ToLower(value), "error:") {
@@ -185,6 +189,10 @@ func (w *Window) ConnectSignals() {
 				return
 			}
 
+			if _, ok := w.Toolbar.People[query]; ok {
+				return
+			}
+
 			w.Search(query, query, 1)
 		}
 	})
@@ -196,6 +204,13 @@ func (w *Window) ConnectSignals() {
 		go w.Client.Complete(w.Toolbar, text)
 	})
 
+	w.Completer.ConnectActivated(func(text string) {
+		if p, ok := w.Toolbar.People[text]; ok {
+			w.Toolbar.Input.SetText("")
+			w.Person(p)
+		}
+	})
+
 	w.Toolbar.ConnectFinished(func(data string) {
 		if data != "" && data != "empty" {
 			w.Toolbar.Complete(w, data)
@@ -208,6 +223,12 @@ func (w *Window) ConnectSignals() {
 			return
 		}
 
+		if p, ok := w.Toolbar.People[query]; ok {
+			w.Toolbar.Input.SetText("")
+			w.Person(p)
+			return
+		}
+
 		w.Search(query, query, 1)
 	})
 
@@ -234,25 +255,25 @@ func (w *Window) ConnectSignals() {
 			}
 
 			t.Widget.Started = true
-			go w.Client.Search(t.Widget, t.Query, 0, 1, w.Settings.Days, 1, w.Settings.TPBHost, w.Settings.EZTVHost, sortBy, media)
+			go w.Client.Search(t.Handle, t.Widget, t.Query, 0, 1, w.Settings.Days, 1, w.Settings.TPBHost, w.Settings.EZTVHost, sortBy, media, w.Filter.JSON())
 		} else if t.Category != 0 {
 			w.setLoading(t.Widget.QWidget_PTR(), true)
 			w.LabelStatus.SetText(textMeta)
 
 			t.Widget.Started = true
-			go w.Client.Top(t.Widget, t.Category, 0, 1, w.Settings.Days, w.Settings.TPBHost)
+			go w.Client.Top(t.Handle, t.Widget, t.Category, 0, 1, w.Settings.Days, w.Settings.TPBHost, w.Filter.JSON())
 		} else if t.Genre != 0 {
 			w.setLoading(t.Widget.QWidget_PTR(), true)
 			w.LabelStatus.SetText(textMeta)
 
 			t.Widget.Started = true
-			go w.Client.Genre(t.Widget, t.Genre, 0, 1, w.Settings.Days, w.Settings.TPBHost)
+			go w.Client.Genre(t.Handle, t.Widget, t.Genre, 0, 1, w.Settings.Days, w.Settings.TPBHost, w.Filter.JSON())
 		} else if t.Movie.Id != 0 {
 			w.setLoading(t.Widget2.QWidget_PTR(), true)
 			w.LabelStatus.SetText(textMovieMeta)
 
 			t.Widget2.Started = true
-			go w.Client.Summary(t.Widget2, t.Movie)
+			go w.Client.Summary(t.Handle, t.Widget2, t.Movie)
 		}
 	})
 
@@ -269,7 +290,7 @@ func (w *Window) ConnectSignals() {
 			}
 
 			sortBy := strings.ToLower(action.Text())
-			go w.Client.Search(t.Widget, t.Query, 0, 0, w.Settings.Days, 1, w.Settings.TPBHost, w.Settings.EZTVHost, sortBy, media)
+			go w.Client.Search(t.Handle, t.Widget, t.Query, 0, 0, w.Settings.Days, 1, w.Settings.TPBHost, w.Settings.EZTVHost, sortBy, media, w.Filter.JSON())
 		}
 	})
 
@@ -301,6 +322,11 @@ func (w *Window) ConnectSignals() {
 		w.Settings.Show()
 	})
 
+	w.Toolbar.Filter.ConnectClicked(func(bool) {
+		w.Filter.Set()
+		w.Filter.Show()
+	})
+
 	w.Toolbar.Log.ConnectClicked(func(bool) {
 		w.Log.Show()
 	})
@@ -343,9 +369,7 @@ func (w *Window) ConnectSignals() {
 			}
 
 			if t.Widget2.Started {
-				go func() {
-					bukanir.Cancel()
-				}()
+				t.Handle.Cancel()
 			}
 		} else if t.Widget != nil {
 			t.Widget.DisconnectFinished()
@@ -357,9 +381,7 @@ func (w *Window) ConnectSignals() {
 			}
 
 			if t.Widget.Started {
-				go func() {
-					bukanir.Cancel()
-				}()
+				t.Handle.Cancel()
 			}
 		}
 
@@ -416,9 +438,10 @@ func (w *Window) Top(title string, category int) {
 	w.LabelStatus.SetText(textMeta)
 
 	tab.Started = true
-	tabs = append(tabs, Tab{"", category, 0, bukanir.TMovie{}, tab, nil})
+	handle := bukanir.NewHandle()
+	tabs = append(tabs, Tab{"", category, 0, bukanir.TMovie{}, tab, nil, handle})
 
-	go w.Client.Top(tab, category, 0, 0, w.Settings.Days, w.Settings.TPBHost)
+	go w.Client.Top(handle, tab, category, 0, 0, w.Settings.Days, w.Settings.TPBHost, w.Filter.JSON())
 }
 
 // Search movies
@@ -459,7 +482,8 @@ func (w *Window) Search(title, query string, pages int) {
 	w.LabelStatus.SetText(textMeta)
 
 	tab.Started = true
-	tabs = append(tabs, Tab{query, 0, 0, bukanir.TMovie{}, tab, nil})
+	handle := bukanir.NewHandle()
+	tabs = append(tabs, Tab{query, 0, 0, bukanir.TMovie{}, tab, nil, handle})
 
 	var sortBy string
 	for _, a := range w.Toolbar.SortBy.Menu().Actions() {
@@ -475,7 +499,7 @@ func (w *Window) Search(title, query string, pages int) {
 		}
 	}
 
-	go w.Client.Search(tab, query, 0, 0, w.Settings.Days, pages, w.Settings.TPBHost, w.Settings.EZTVHost, sortBy, media)
+	go w.Client.Search(handle, tab, query, 0, 0, w.Settings.Days, pages, w.Settings.TPBHost, w.Settings.EZTVHost, sortBy, media, w.Filter.JSON())
 }
 
 // Genre search movies by genre
@@ -510,9 +534,10 @@ func (w *Window) Genre(title string, id int) {
 	w.LabelStatus.SetText(tr("Downloading torrents metadata..."))
 
 	tab.Started = true
-	tabs = append(tabs, Tab{"", 0, id, bukanir.TMovie{}, tab, nil})
+	handle := bukanir.NewHandle()
+	tabs = append(tabs, Tab{"", 0, id, bukanir.TMovie{}, tab, nil, handle})
 
-	go w.Client.Genre(tab, id, 0, 0, w.Settings.Days, w.Settings.TPBHost)
+	go w.Client.Genre(handle, tab, id, 0, 0, w.Settings.Days, w.Settings.TPBHost, w.Filter.JSON())
 }
 
 // Cast search movies by cast
@@ -547,9 +572,19 @@ func (w *Window) Cast(title string, id int) {
 	w.LabelStatus.SetText(tr("Downloading torrents metadata..."))
 
 	tab.Started = true
-	tabs = append(tabs, Tab{"", 0, id, bukanir.TMovie{}, tab, nil})
+	handle := bukanir.NewHandle()
+	tabs = append(tabs, Tab{"", 0, id, bukanir.TMovie{}, tab, nil, handle})
 
-	go w.Client.Cast(tab, id, 0, 0, w.Settings.Days, w.Settings.TPBHost)
+	go w.Client.Cast(handle, tab, id, 0, 0, w.Settings.Days, w.Settings.TPBHost)
+}
+
+// Person search movies by person, actors by cast and others by crew
+func (w *Window) Person(p bukanir.TPerson) {
+	if p.Department == "Acting" {
+		w.Cast(p.Name, p.Id)
+	} else {
+		w.Crew(p.Name, p.Id)
+	}
 }
 
 // Crew search movies by crew
@@ -584,9 +619,10 @@ func (w *Window) Crew(title string, id int) {
 	w.LabelStatus.SetText(tr("Downloading torrents metadata..."))
 
 	tab.Started = true
-	tabs = append(tabs, Tab{"", 0, id, bukanir.TMovie{}, tab, nil})
+	handle := bukanir.NewHandle()
+	tabs = append(tabs, Tab{"", 0, id, bukanir.TMovie{}, tab, nil, handle})
 
-	go w.Client.Crew(tab, id, 0, 0, w.Settings.Days, w.Settings.TPBHost)
+	go w.Client.Crew(handle, tab, id, 0, 0, w.Settings.Days, w.Settings.TPBHost)
 }
 
 // Summary shows movie summary
@@ -608,17 +644,10 @@ func (w *Window) Summary(movie bukanir.TMovie) {
 			})
 
 			summary.Cast.ConnectButtonClicked2(func(id int) {
-				var s bukanir.TSummary
-				err := json.Unmarshal([]byte(data), &s)
-				if err != nil {
-					log.Printf("ERROR: Unmarshal: %s\n", err.Error())
-					return
-				}
-
-				w.Cast(s.Cast[id], s.CastIds[id])
+				w.Cast(summary.Details.Cast[id], summary.Details.CastIds[id])
 			})
 
-			reply := w.Manager.Get(network.NewQNetworkRequest(core.NewQUrl3(movie.PosterXLarge, core.QUrl__TolerantMode)))
+			reply := w.Manager.Get(network.NewQNetworkRequest(core.NewQUrl3(bukanir.LocalImage(movie.PosterXLarge), core.QUrl__TolerantMode)))
 			reply.ConnectFinished(func() {
 				if reply.IsReadable() && reply.Error() == network.QNetworkReply__NoError {
 					data := reply.ReadAll()
@@ -766,7 +795,7 @@ func (w *Window) Summary(movie bukanir.TMovie) {
 		})
 
 		go func() {
-			url, err := bukanir.Trailer(summary.Video)
+			url, err := bukanir.Trailer(summary.Video, "720p", bukanir.TrailerCache)
 			if err != nil {
 				log.Printf("ERROR: Trailer: %s\n", err.Error())
 				summary.Player.Shutdown()
@@ -790,9 +819,10 @@ func (w *Window) Summary(movie bukanir.TMovie) {
 	w.LabelStatus.SetText(tr("Downloading movie metadata..."))
 
 	summary.Started = true
-	tabs = append(tabs, Tab{"", 0, 0, movie, nil, summary})
+	handle := bukanir.NewHandle()
+	tabs = append(tabs, Tab{"", 0, 0, movie, nil, summary, handle})
 
-	go w.Client.Summary(summary, movie)
+	go w.Client.Summary(handle, summary, movie)
 }
 
 // TailLog watch log file changes
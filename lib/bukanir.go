@@ -2,62 +2,246 @@
 package bukanir
 
 import (
-	"archive/zip"
-	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
-	"io"
 	"io/ioutil"
 	"log"
 	"os"
 	"os/user"
-	"path/filepath"
 	"runtime"
 	"sort"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
-
-	"github.com/kkdai/youtube"
 )
 
 // TMovie type
 type TMovie struct {
-	Id           int    `json:"id"`
-	Title        string `json:"title"`
-	Year         string `json:"year"`
-	PosterSmall  string `json:"posterSmall"`
-	PosterMedium string `json:"posterMedium"`
-	PosterLarge  string `json:"posterLarge"`
-	PosterXLarge string `json:"posterXLarge"`
-	Size         int64  `json:"size"`
-	SizeHuman    string `json:"sizeHuman"`
-	Seeders      int    `json:"seeders"`
-	MagnetLink   string `json:"magnetLink"`
-	Release      string `json:"release"`
-	Category     int    `json:"category"`
-	Season       int    `json:"season"`
-	Episode      int    `json:"episode"`
-	Quality      string `json:"quality"`
+	Id             int          `json:"id"`
+	Title          string       `json:"title"`
+	Year           string       `json:"year"`
+	PosterSmall    string       `json:"posterSmall"`
+	PosterMedium   string       `json:"posterMedium"`
+	PosterLarge    string       `json:"posterLarge"`
+	PosterXLarge   string       `json:"posterXLarge"`
+	BackdropSmall  string       `json:"backdropSmall"`
+	BackdropMedium string       `json:"backdropMedium"`
+	BackdropLarge  string       `json:"backdropLarge"`
+	Size           int64        `json:"size"`
+	SizeHuman      string       `json:"sizeHuman"`
+	Seeders        int          `json:"seeders"`
+	MagnetLink     string       `json:"magnetLink"`
+	Release        string       `json:"release"`
+	Category       int          `json:"category"`
+	Season         int          `json:"season"`
+	Episode        int          `json:"episode"`
+	Quality        string       `json:"quality"`
+	ReleaseInfo    TReleaseInfo `json:"releaseInfo"`
+	Provider       string       `json:"provider"`
+	Leechers       int          `json:"leechers"`
+	Added          int64        `json:"added"`
+	Score          float64      `json:"score"`
+	ScoreBreakdown TScore       `json:"scoreBreakdown"`
+	Confidence     float64      `json:"confidence"`
+	Verified       bool         `json:"verified"`
+	ImdbId         string       `json:"imdbId"`
+	ImdbRating     float64      `json:"imdbRating"`
+	ImdbVotes      int          `json:"imdbVotes"`
+}
+
+// TReleaseInfo type
+type TReleaseInfo struct {
+	Title       string   `json:"title"`
+	Year        string   `json:"year"`
+	Resolution  string   `json:"resolution"`
+	Source      string   `json:"source"`
+	Codec       string   `json:"codec"`
+	HDR         string   `json:"hdr"`
+	DolbyVision bool     `json:"dolbyVision"`
+	Audio       []string `json:"audio"`
+	Languages   []string `json:"languages"`
+	Proper      bool     `json:"proper"`
+	Repack      bool     `json:"repack"`
+	Group       string   `json:"group"`
+	Season      int      `json:"season"`
+	SeasonEnd   int      `json:"seasonEnd"`
+	Episode     int      `json:"episode"`
+	EpisodeEnd  int      `json:"episodeEnd"`
+	SeasonPack  bool     `json:"seasonPack"`
+}
+
+// TGroup type
+type TGroup struct {
+	Id           int        `json:"id"`
+	Title        string     `json:"title"`
+	Year         string     `json:"year"`
+	PosterSmall  string     `json:"posterSmall"`
+	PosterMedium string     `json:"posterMedium"`
+	PosterLarge  string     `json:"posterLarge"`
+	PosterXLarge string     `json:"posterXLarge"`
+	Category     int        `json:"category"`
+	Season       int        `json:"season"`
+	Episode      int        `json:"episode"`
+	Best         int        `json:"best"`
+	Releases     []TRelease `json:"releases"`
+}
+
+// TRelease type
+type TRelease struct {
+	Release     string       `json:"release"`
+	MagnetLink  string       `json:"magnetLink"`
+	Size        int64        `json:"size"`
+	SizeHuman   string       `json:"sizeHuman"`
+	Seeders     int          `json:"seeders"`
+	Quality     string       `json:"quality"`
+	Provider    string       `json:"provider"`
+	ReleaseInfo TReleaseInfo `json:"releaseInfo"`
+}
+
+// TScore type, weighted score components
+type TScore struct {
+	Seeders    float64 `json:"seeders"`
+	Resolution float64 `json:"resolution"`
+	Source     float64 `json:"source"`
+	Size       float64 `json:"size"`
+	Confidence float64 `json:"confidence"`
+	Provider   float64 `json:"provider"`
+	Age        float64 `json:"age"`
 }
 
 // TSummary type
 type TSummary struct {
+	Id             int      `json:"id"`
+	Cast           []string `json:"cast"`
+	CastIds        []int    `json:"castIds"`
+	Genre          []string `json:"genre"`
+	Video          string   `json:"video"`
+	Director       string   `json:"director"`
+	DirectorId     int      `json:"directorId"`
+	Rating         float64  `json:"rating"`
+	TagLine        string   `json:"tagline"`
+	Overview       string   `json:"overview"`
+	Runtime        int      `json:"runtime"`
+	ImdbId         string   `json:"imdbId"`
+	ImdbRating     float64  `json:"imdbRating"`
+	ImdbVotes      int      `json:"imdbVotes"`
+	CollectionId   int      `json:"collectionId"`
+	CollectionName string   `json:"collectionName"`
+	Backdrop       string   `json:"backdrop"`
+	ReleaseDate    string   `json:"releaseDate"`
+	Language       string   `json:"language"`
+	Languages      []string `json:"languages"`
+	Countries      []string `json:"countries"`
+	Status         string   `json:"status"`
+	EpisodeTitle   string   `json:"episodeTitle"`
+	EpisodeStill   string   `json:"episodeStill"`
+	Stale          bool     `json:"stale"`
+}
+
+// TDiscover type, discover query for movie or tv media, genres are all required unless AnyGenre is set,
+// zero values are not used and empty SortBy sorts by popularity
+type TDiscover struct {
+	Media        string  `json:"media"`
+	Genres       []int   `json:"genres"`
+	AnyGenre     bool    `json:"anyGenre"`
+	YearFrom     int     `json:"yearFrom"`
+	YearTo       int     `json:"yearTo"`
+	MinVote      float64 `json:"minVote"`
+	MinVoteCount int     `json:"minVoteCount"`
+	Language     string  `json:"language"`
+	MinRuntime   int     `json:"minRuntime"`
+	MaxRuntime   int     `json:"maxRuntime"`
+	SortBy       string  `json:"sortBy"`
+}
+
+// TPerson type
+type TPerson struct {
 	Id         int      `json:"id"`
-	Cast       []string `json:"cast"`
-	CastIds    []int    `json:"castIds"`
-	Genre      []string `json:"genre"`
-	Video      string   `json:"video"`
-	Director   string   `json:"director"`
-	DirectorId int      `json:"directorId"`
-	Rating     float64  `json:"rating"`
-	TagLine    string   `json:"tagline"`
-	Overview   string   `json:"overview"`
-	Runtime    int      `json:"runtime"`
-	ImdbId     string   `json:"imdbId"`
+	Name       string   `json:"name"`
+	Department string   `json:"department"`
+	Profile    string   `json:"profile"`
+	KnownFor   []string `json:"knownFor"`
+}
+
+// TPersonDetails type
+type TPersonDetails struct {
+	Id           int       `json:"id"`
+	Name         string    `json:"name"`
+	Department   string    `json:"department"`
+	Biography    string    `json:"biography"`
+	Birthday     string    `json:"birthday"`
+	Deathday     string    `json:"deathday"`
+	PlaceOfBirth string    `json:"placeOfBirth"`
+	Profile      string    `json:"profile"`
+	KnownFor     []string  `json:"knownFor"`
+	Cast         []TCredit `json:"cast"`
+	Crew         []TCredit `json:"crew"`
+	Stale        bool      `json:"stale"`
+}
+
+// TCredit type
+type TCredit struct {
+	Id        int    `json:"id"`
+	Title     string `json:"title"`
+	Year      string `json:"year"`
+	Category  int    `json:"category"`
+	Character string `json:"character"`
+	Job       string `json:"job"`
+	Poster    string `json:"poster"`
+}
+
+// TSeason type
+type TSeason struct {
+	Season   int    `json:"season"`
+	Name     string `json:"name"`
+	AirDate  string `json:"airDate"`
+	Episodes int    `json:"episodes"`
+	Overview string `json:"overview"`
+	Poster   string `json:"poster"`
+}
+
+// TEpisode type
+type TEpisode struct {
+	Season   int       `json:"season"`
+	Episode  int       `json:"episode"`
+	Title    string    `json:"title"`
+	AirDate  string    `json:"airDate"`
+	Overview string    `json:"overview"`
+	Still    string    `json:"still"`
+	Torrent  *TRelease `json:"torrent"`
+}
+
+// TCollection type
+type TCollection struct {
+	Id       int     `json:"id"`
+	Name     string  `json:"name"`
+	Overview string  `json:"overview"`
+	Poster   string  `json:"poster"`
+	Backdrop string  `json:"backdrop"`
+	Parts    []TPart `json:"parts"`
+	Stale    bool    `json:"stale"`
+}
+
+// TPart type, part of a collection, torrent is nil when part is not available
+type TPart struct {
+	Id       int       `json:"id"`
+	Title    string    `json:"title"`
+	Year     string    `json:"year"`
+	Overview string    `json:"overview"`
+	Poster   string    `json:"poster"`
+	Torrent  *TRelease `json:"torrent"`
+}
+
+// TVideo type
+type TVideo struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Site     string `json:"site"`
+	Language string `json:"language"`
+	Country  string `json:"country"`
+	Size     int    `json:"size"`
+	Official bool   `json:"official"`
 }
 
 // TSubtitle type
@@ -74,12 +258,14 @@ type TSubtitle struct {
 type TItem struct {
 	Title string `json:"title"`
 	Year  string `json:"year"`
+	Stale bool   `json:"stale"`
 }
 
 // TGenre type
 type TGenre struct {
-	Id   int    `json:"id"`
-	Name string `json:"name"`
+	Id    int    `json:"id"`
+	Name  string `json:"name"`
+	Stale bool   `json:"stale"`
 }
 
 // TTorrent type
@@ -94,6 +280,63 @@ type TTorrent struct {
 	Category       int
 	Season         int
 	Episode        int
+	Provider       string
+	Leechers       int
+	Added          int64
+}
+
+// TFilter type
+type TFilter struct {
+	MinResolution int      `json:"minResolution"`
+	MaxResolution int      `json:"maxResolution"`
+	Sources       []string `json:"sources"`
+	MinSize       int64    `json:"minSize"`
+	MaxSize       int64    `json:"maxSize"`
+	MinSeeders    int      `json:"minSeeders"`
+	Codecs        []string `json:"codecs"`
+	Required      []string `json:"required"`
+	Excluded      []string `json:"excluded"`
+	MinImdbRating float64  `json:"minImdbRating"`
+	MinImdbVotes  int      `json:"minImdbVotes"`
+}
+
+// TParental type, parental controls with maximum allowed movie and tv certification in country,
+// empty maximum allows all certifications
+type TParental struct {
+	Country        string `json:"country"`
+	MaxMovieRating string `json:"maxMovieRating"`
+	MaxTvRating    string `json:"maxTvRating"`
+	HideAdult      bool   `json:"hideAdult"`
+	HideUnrated    bool   `json:"hideUnrated"`
+}
+
+// TCertification type
+type TCertification struct {
+	Certification string `json:"certification"`
+	Meaning       string `json:"meaning"`
+	Order         int    `json:"order"`
+}
+
+// TProfile type
+type TProfile struct {
+	Resolution int      `json:"resolution"`
+	Sources    []string `json:"sources"`
+	Codecs     []string `json:"codecs"`
+	Languages  []string `json:"languages"`
+	HDR        bool     `json:"hdr"`
+	MaxSize    int64    `json:"maxSize"`
+}
+
+// TWeights type
+type TWeights struct {
+	Seeders       float64            `json:"seeders"`
+	Resolution    float64            `json:"resolution"`
+	Source        float64            `json:"source"`
+	Size          float64            `json:"size"`
+	Confidence    float64            `json:"confidence"`
+	Provider      float64            `json:"provider"`
+	Age           float64            `json:"age"`
+	ProviderTrust map[string]float64 `json:"providerTrust"`
 }
 
 // TConfig type
@@ -115,6 +358,34 @@ func (a BySeeders) Len() int           { return len(a) }
 func (a BySeeders) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a BySeeders) Less(i, j int) bool { return a[i].Seeders > a[j].Seeders }
 
+// Sort movies by IMDb rating
+type ByImdbRating []TMovie
+
+func (a ByImdbRating) Len() int           { return len(a) }
+func (a ByImdbRating) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a ByImdbRating) Less(i, j int) bool { return a[i].ImdbRating > a[j].ImdbRating }
+
+// Sort movies by IMDb votes
+type ByImdbVotes []TMovie
+
+func (a ByImdbVotes) Len() int           { return len(a) }
+func (a ByImdbVotes) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a ByImdbVotes) Less(i, j int) bool { return a[i].ImdbVotes > a[j].ImdbVotes }
+
+// Sort releases by seeders
+type ByRSeeders []TRelease
+
+func (a ByRSeeders) Len() int           { return len(a) }
+func (a ByRSeeders) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a ByRSeeders) Less(i, j int) bool { return a[i].Seeders > a[j].Seeders }
+
+// Sort movies by score
+type ByRank []TMovie
+
+func (a ByRank) Len() int           { return len(a) }
+func (a ByRank) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a ByRank) Less(i, j int) bool { return a[i].Score > a[j].Score }
+
 // Sort subtitles by score
 type ByScore []TSubtitle
 
@@ -198,6 +469,23 @@ var Categories = []int{
 	CategoryHDTV,
 }
 
+// TMDB lists
+const (
+	ListTrendingDay  = "trending_day"
+	ListTrendingWeek = "trending_week"
+	ListNowPlaying   = "now_playing"
+	ListUpcoming     = "upcoming"
+	ListAiringToday  = "airing_today"
+	ListOnTheAir     = "on_the_air"
+)
+
+// Trailer streams, proxied and cached trailers are served by local HTTP server
+const (
+	TrailerDirect = 0
+	TrailerProxy  = 1
+	TrailerCache  = 2
+)
+
 // TPB onion url
 var TpbTor string = "piratebayo3klnzokct3wt5yyxb2vpebbuyjl7m623iaxmqhsd52coid.onion"
 
@@ -217,28 +505,15 @@ var EztvHosts = []string{
 
 // Globals
 var (
-	movies        []TMovie
-	subtitles     []TSubtitle
-	autocompletes []TItem
-	popular       []TItem
-	toprated      []TItem
-	genres        []TGenre
-	bygenre       []TMovie
-	bycast        []TMovie
-	bycrew        []TMovie
-	torrents      []TTorrent
-	details       TSummary
-
-	wg, wgt, wgs sync.WaitGroup
-
-	verbose    bool
-	throttle   chan int
-	cancelchan chan bool
+	defaultClient = NewClient()
+
+	verbose bool
+
+	rootmu      sync.Mutex
+	ctx, cancel = context.WithCancel(context.Background())
 
 	ttor     *tor
 	ttorrent *torrent
-
-	ctx, cancel = context.WithCancel(context.TODO())
 )
 
 // init starts tor
@@ -280,365 +555,65 @@ func init() {
 }
 
 // Category returns movies by category
-func Category(category int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string) (string, error) {
-	if force != 1 {
-		cache := getCache(strconv.Itoa(category), cacheDir, cacheDays)
-		if cache != nil {
-			return string(cache[:]), nil
-		}
-	}
-
-	cancelchan = make(chan bool)
-	ctx, cancel = context.WithCancel(context.TODO())
-	torrents = make([]TTorrent, 0)
-
-	if tpbHost == "" {
-		tpbHost = getTpbHost()
-	}
-
-	wgt.Add(1)
-	go tpbTop(category, tpbHost)
-	wgt.Wait()
-
-	if limit > 0 {
-		if limit > len(torrents) {
-			limit = len(torrents)
-		}
-		torrents = torrents[0:limit]
-	}
-
-	if verbose {
-		log.Printf("BUK: Total torrents: %d\n", len(torrents))
-	}
-
-	movies = make([]TMovie, 0)
-
-	md := NewTmdb(tmdbApiKey)
-	config, err := md.GetConfig()
-	if err != nil {
-		log.Printf("ERROR: TMDB GetConfig: %s\n", err.Error())
-		return "empty", err
-	}
-
-	throttle = make(chan int, 3*runtime.NumCPU())
-
-	if len(torrents) > 0 {
-		for _, torrent := range torrents {
-			throttle <- 1
-			wg.Add(1)
-
-			if torrent.Category == CategoryTV || torrent.Category == CategoryHDTV {
-				go tmdbSearchTv(torrent, config)
-			} else {
-				go tmdbSearchMovie(torrent, config)
-			}
-		}
-
-		wg.Wait()
-	}
-
-	if verbose {
-		log.Printf("BUK: Total movies: %d\n", len(movies))
-	}
-
-	sort.Sort(BySeeders(movies))
-
-	js, err := json.MarshalIndent(movies, "", "    ")
-	if err != nil {
-		return "empty", err
-	}
-
-	if len(movies) > 0 {
-		saveCache(strconv.Itoa(category), js, cacheDir)
-	}
-
-	return string(js[:]), nil
+func Category(category int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, filter string) (string, error) {
+	return root().Category(category, limit, force, cacheDir, cacheDays, tpbHost, filter)
 }
 
 // Search returns movies by search query
-func Search(query string, limit int, force int, cacheDir string, cacheDays int64, pages int, tpbHost, eztvHost, sortBy, media string) (string, error) {
-	query = strings.TrimSpace(query)
-	if force != 1 {
-		cache := getCache(query, cacheDir, cacheDays)
-		if cache != nil {
-			var movs []TMovie
-			err := json.Unmarshal(cache, &movs)
-			if err != nil {
-				return "empty", err
-			}
-
-			if sortBy == "seeders" || sortBy == "" {
-				sort.Sort(BySeeders(movs))
-			} else if sortBy == "episodes" {
-				s := BySeasonEpisode{}
-				s.Sort(movs)
-			}
-
-			js, err := json.MarshalIndent(movs, "", "    ")
-			if err != nil {
-				return "empty", err
-			}
-
-			return string(js[:]), nil
-		}
-	}
-
-	cancelchan = make(chan bool)
-	ctx, cancel = context.WithCancel(context.TODO())
-	torrents = make([]TTorrent, 0)
-
-	if tpbHost == "" {
-		tpbHost = getTpbHost()
-	} else {
-		if verbose {
-			log.Printf("TPB: Using host %s\n", tpbHost)
-		}
-	}
-
-	if eztvHost == "" {
-		eztvHost = getEztvHost()
-	} else {
-		if verbose {
-			log.Printf("EZTV: Using host %s\n", eztvHost)
-		}
-	}
-
-	if media == "all" {
-		wgt.Add(pages + 1)
-		for n := 0; n < pages; n++ {
-			go tpbSearch(query, n, tpbHost, media)
-		}
-		go eztvSearch(query, eztvHost)
-		wgt.Wait()
-	} else if media == "movies" {
-		wgt.Add(pages)
-		for n := 0; n < pages; n++ {
-			go tpbSearch(query, n, tpbHost, media)
-		}
-		wgt.Wait()
-	} else if media == "episodes" {
-		wgt.Add(1)
-		go eztvSearch(query, eztvHost)
-		wgt.Wait()
-	}
-
-	if verbose {
-		log.Printf("BUK: Total torrents: %d\n", len(torrents))
-	}
+func Search(query string, limit int, force int, cacheDir string, cacheDays int64, pages int, tpbHost, eztvHost, sortBy, media, filter string) (string, error) {
+	return root().Search(query, limit, force, cacheDir, cacheDays, pages, tpbHost, eztvHost, sortBy, media, filter)
+}
 
-	movies = make([]TMovie, 0)
+// SearchStream is like Search, but returns immediately and delivers results through callback
+func SearchStream(query string, limit int, force int, cacheDir string, cacheDays int64, pages int, tpbHost, eztvHost, sortBy, media, filter string, cb Callback) {
+	root().SearchStream(query, limit, force, cacheDir, cacheDays, pages, tpbHost, eztvHost, sortBy, media, filter, cb)
+}
 
-	md := NewTmdb(tmdbApiKey)
-	config, err := md.GetConfig()
+// Group groups movies returned by Search, Category or Genre, one entry per title with all releases nested.
+// Best is the index of the release that best matches the preference profile.
+func Group(movies string, profile string) (string, error) {
+	p, err := getProfile(profile)
 	if err != nil {
-		log.Printf("ERROR: TMDB GetConfig: %s\n", err.Error())
 		return "empty", err
 	}
 
-	throttle = make(chan int, 3*runtime.NumCPU())
-
-	if len(torrents) > 0 {
-		for _, torrent := range torrents {
-			throttle <- 1
-			wg.Add(1)
-
-			if torrent.Category == CategoryTV || torrent.Category == CategoryHDTV {
-				go tmdbSearchTv(torrent, config)
-			} else {
-				go tmdbSearchMovie(torrent, config)
-			}
-		}
-		wg.Wait()
-	}
-
-	if verbose {
-		log.Printf("BUK: Total movies: %d\n", len(movies))
-	}
-
-	if sortBy == "seeders" || sortBy == "" {
-		sort.Sort(BySeeders(movies))
-	} else if sortBy == "episodes" {
-		s := BySeasonEpisode{}
-		s.Sort(movies)
-	}
-
-	js, err := json.MarshalIndent(movies, "", "    ")
+	var movs []TMovie
+	err = json.Unmarshal([]byte(movies), &movs)
 	if err != nil {
 		return "empty", err
 	}
 
-	if len(movies) > 0 {
-		saveCache(query, js, cacheDir)
-	}
-
-	return string(js[:]), nil
+	return toJSON(defaultClient.Group(movs, p), nil)
 }
 
 // Summary returns movie summary
 func Summary(id int, category int, season int, episode int) (string, error) {
-	cancelchan = make(chan bool)
-	ctx, cancel = context.WithCancel(context.TODO())
-	details = TSummary{}
-
-	wg.Add(1)
-	go tmdbSummary(id, category, season, episode)
-	wg.Wait()
-
-	if details.Id == 0 {
-		return "empty", errors.New("No results")
-	}
-
-	js, err := json.MarshalIndent(details, "", "    ")
-	if err != nil {
-		return "empty", err
-	}
-
-	return string(js[:]), nil
+	return root().Summary(id, category, season, episode)
 }
 
 // Subtitle returns movie subtitles
 func Subtitle(movie string, year string, release string, language string, category int, season int, episode int, imdbID string) (string, error) {
-	subtitles = make([]TSubtitle, 0)
-	language = strings.ToLower(language)
-
-	wgs.Add(3)
-	go podnapisi(movie, year, release, language, category, season, episode)
-	go opensubtitles(movie, imdbID, year, release, language, category, season, episode)
-	go subscene(movie, year, release, language, category, season, episode)
-	wgs.Wait()
-
-	if len(subtitles) == 0 && language != "english" {
-		if verbose {
-			log.Printf("SUB: No %s subtitles, trying with english\n", language)
-		}
-
-		wgs.Add(3)
-		go podnapisi(movie, year, release, "english", category, season, episode)
-		go opensubtitles(movie, imdbID, year, release, "english", category, season, episode)
-		go subscene(movie, year, release, "english", category, season, episode)
-		wgs.Wait()
-	}
-
-	if verbose {
-		log.Printf("SUB: Total subtitles: %d\n", len(subtitles))
-	}
-
-	sort.Sort(ByScore(subtitles))
-
-	js, err := json.MarshalIndent(subtitles, "", "    ")
-	if err != nil {
-		return "empty", err
-	}
-
-	return string(js[:]), nil
+	return root().Subtitle(movie, year, release, language, category, season, episode, imdbID)
 }
 
 // UnzipSubtitle unzip subtitle
 func UnzipSubtitle(url string, dest string) (string, error) {
-	res, err := getResponse(url)
-	if err != nil {
-		log.Printf("ERROR: getResponse %s: %v\n", url, err.Error())
-		return "empty", err
-	}
-
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		log.Printf("ERROR: ReadAll: %s\n", err.Error())
-		return "empty", err
-	}
-	defer res.Body.Close()
-
-	z, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
-	if err != nil {
-		log.Printf("ERROR: NewReader: %s\n", err.Error())
-		return "empty", err
-	}
-
-	exts := []string{".srt", ".ass", ".ssa"}
-	if runtime.GOOS != "android" {
-		exts = append(exts, ".sub")
-	}
-
-	for _, f := range z.File {
-		ext := filepath.Ext(f.Name)
-		for _, e := range exts {
-			if e == ext {
-				dst, err := os.Create(filepath.Join(dest, f.Name))
-				if err != nil {
-					return "empty", err
-				}
-
-				src, err := f.Open()
-				if err != nil {
-					return "empty", err
-				}
-
-				_, err = io.Copy(dst, src)
-				if err != nil {
-					return "empty", err
-				}
-
-				_ = dst.Close()
-				_ = src.Close()
-
-				return filepath.Join(dest, f.Name), nil
-			}
-		}
-	}
-
-	return "empty", err
+	return root().UnzipSubtitle(url, dest)
 }
 
 // AutoComplete completes search queries
 func AutoComplete(query string, limit int) (string, error) {
-	autocompletes = make([]TItem, 0)
-	wg.Add(1)
-	go tmdbAutoComplete(query)
-	wg.Wait()
-
-	if limit > 0 {
-		if limit > len(autocompletes) {
-			limit = len(autocompletes)
-		}
-		autocompletes = autocompletes[0:limit]
-	}
-
-	js, err := json.MarshalIndent(autocompletes, "", "    ")
-	if err != nil {
-		return "empty", err
-	}
-
-	return string(js[:]), nil
+	return root().AutoComplete(query, limit)
 }
 
 // Popular returns popular movies
 func Popular() (string, error) {
-	popular = make([]TItem, 0)
-	wg.Add(1)
-	go tmdbPopular()
-	wg.Wait()
-
-	js, err := json.MarshalIndent(popular, "", "    ")
-	if err != nil {
-		return "empty", err
-	}
-
-	return string(js[:]), nil
+	return root().Popular()
 }
 
 // TopRated returns top rated movies
 func TopRated() (string, error) {
-	toprated = make([]TItem, 0)
-	wg.Add(1)
-	go tmdbTopRated()
-	wg.Wait()
-
-	js, err := json.MarshalIndent(toprated, "", "    ")
-	if err != nil {
-		return "empty", err
-	}
-
-	return string(js[:]), nil
+	return root().TopRated()
 }
 
 // Languages returns all supported languages
@@ -654,177 +629,120 @@ func Languages() string {
 
 // Genres returns all genres
 func Genres() (string, error) {
-	genres = make([]TGenre, 0)
-	wg.Add(1)
-	go tmdbGetGenres()
-	wg.Wait()
-
-	js, err := json.MarshalIndent(genres, "", "    ")
-	if err != nil {
-		return "empty", err
-	}
-
-	return string(js[:]), nil
+	return root().Genres()
 }
 
 // Genre returns movies by genre
-func Genre(id int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string) (string, error) {
-	if force != 1 {
-		cache := getCache("genre"+strconv.Itoa(id), cacheDir, cacheDays)
-		if cache != nil {
-			return string(cache[:]), nil
-		}
-	}
-
-	cancelchan = make(chan bool)
-	ctx, cancel = context.WithCancel(context.TODO())
-	bygenre = make([]TMovie, 0)
-
-	if tpbHost == "" {
-		tpbHost = getTpbHost()
-	} else {
-		if verbose {
-			log.Printf("TPB: Using host %s\n", tpbHost)
-		}
-	}
-
-	wg.Add(1)
-	go tmdbByGenre(id, limit, tpbHost)
-	wg.Wait()
-
-	if verbose {
-		log.Printf("BUK: Total movies: %d\n", len(bygenre))
-	}
-
-	sort.Sort(BySeeders(bygenre))
-	js, err := json.MarshalIndent(bygenre, "", "    ")
-	if err != nil {
-		return "empty", err
-	}
-
-	if len(bygenre) > 0 {
-		saveCache("genre"+strconv.Itoa(id), js, cacheDir)
-	}
-
-	return string(js[:]), nil
+func Genre(id int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, filter string) (string, error) {
+	return root().Genre(id, limit, force, cacheDir, cacheDays, tpbHost, filter)
 }
 
 // Cast returns movies by cast
 func Cast(id int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string) (string, error) {
-	if force != 1 {
-		cache := getCache("cast"+strconv.Itoa(id), cacheDir, cacheDays)
-		if cache != nil {
-			return string(cache[:]), nil
-		}
-	}
-
-	cancelchan = make(chan bool)
-	ctx, cancel = context.WithCancel(context.TODO())
-	bycast = make([]TMovie, 0)
+	return root().Cast(id, limit, force, cacheDir, cacheDays, tpbHost)
+}
 
-	if tpbHost == "" {
-		tpbHost = getTpbHost()
-	} else {
-		if verbose {
-			log.Printf("TPB: Using host %s\n", tpbHost)
-		}
-	}
+// Crew returns movies by crew
+func Crew(id int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string) (string, error) {
+	return root().Crew(id, limit, force, cacheDir, cacheDays, tpbHost)
+}
 
-	wg.Add(1)
-	go tmdbWithCast(id, limit, tpbHost)
-	wg.Wait()
+// Similar returns similar movies or tv shows that have a torrent
+func Similar(id int, category int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, filter string) (string, error) {
+	return root().Similar(id, category, limit, force, cacheDir, cacheDays, tpbHost, filter)
+}
 
-	if verbose {
-		log.Printf("BUK: Total movies: %d\n", len(bycast))
-	}
+// Recommendations returns recommended movies or tv shows that have a torrent
+func Recommendations(id int, category int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, filter string) (string, error) {
+	return root().Recommendations(id, category, limit, force, cacheDir, cacheDays, tpbHost, filter)
+}
 
-	sort.Sort(BySeeders(bycast))
-	js, err := json.MarshalIndent(bycast, "", "    ")
-	if err != nil {
-		return "empty", err
-	}
+// SearchPerson returns people matching query
+func SearchPerson(query string, limit int) (string, error) {
+	return root().SearchPerson(query, limit)
+}
 
-	if len(bycast) > 0 {
-		saveCache("cast"+strconv.Itoa(id), js, cacheDir)
-	}
+// Person returns person details with combined movie and tv credits
+func Person(id int) (string, error) {
+	return root().Person(id)
+}
 
-	return string(js[:]), nil
+// UpdateImdbRatings downloads IMDb datasets and rebuilds local index, index that is not older than a week is kept unless force is set
+func UpdateImdbRatings(force bool) error {
+	return root().UpdateImdbRatings(force)
 }
 
-// Crew returns movies by crew
-func Crew(id int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string) (string, error) {
-	if force != 1 {
-		cache := getCache("crew"+strconv.Itoa(id), cacheDir, cacheDays)
-		if cache != nil {
-			return string(cache[:]), nil
-		}
-	}
+// Discover returns movies or tv shows matching discover query that have a torrent
+func Discover(discover string, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, filter string) (string, error) {
+	return root().Discover(discover, limit, force, cacheDir, cacheDays, tpbHost, filter)
+}
 
-	cancelchan = make(chan bool)
-	ctx, cancel = context.WithCancel(context.TODO())
-	bycrew = make([]TMovie, 0)
+// List returns movies and tv shows from TMDB list that have a torrent, list is one of List constants
+func List(list string, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, filter string) (string, error) {
+	return root().List(list, limit, force, cacheDir, cacheDays, tpbHost, filter)
+}
 
-	if tpbHost == "" {
-		tpbHost = getTpbHost()
-	} else {
-		if verbose {
-			log.Printf("TPB: Using host %s\n", tpbHost)
-		}
-	}
+// Collection returns all parts of movie collection in release order, each with the best available torrent
+func Collection(id int, tpbHost string, filter string) (string, error) {
+	return root().Collection(id, tpbHost, filter)
+}
 
-	wg.Add(1)
-	go tmdbWithCrew(id, limit, tpbHost)
-	wg.Wait()
+// Certifications returns movie or tv certifications of country, ordered from least to most restrictive
+func Certifications(country string, tv bool) (string, error) {
+	return root().Certifications(country, tv)
+}
 
-	if verbose {
-		log.Printf("BUK: Total movies: %d\n", len(bycrew))
-	}
+// ShowSeasons returns seasons of tv show
+func ShowSeasons(id int) (string, error) {
+	return root().ShowSeasons(id)
+}
 
-	sort.Sort(BySeeders(bycrew))
-	js, err := json.MarshalIndent(bycrew, "", "    ")
-	if err != nil {
-		return "empty", err
-	}
+// SeasonEpisodes returns episodes of tv show season with the best available torrents
+func SeasonEpisodes(id int, season int, pages int, tpbHost, eztvHost, filter string) (string, error) {
+	return root().SeasonEpisodes(id, season, pages, tpbHost, eztvHost, filter)
+}
 
-	if len(bycrew) > 0 {
-		saveCache("crew"+strconv.Itoa(id), js, cacheDir)
-	}
+// Trailers returns TMDB videos of movie or tv show
+func Trailers(id int, category int) (string, error) {
+	return root().Trailers(id, category)
+}
 
-	return string(js[:]), nil
+// Trailer returns video stream url in preferred quality, i.e. "720p", stream is one of Trailer streams
+func Trailer(videoId string, quality string, stream int) (string, error) {
+	return root().Trailer(videoId, quality, stream)
 }
 
-// Trailer returns extracted video url
-func Trailer(videoId string) (string, error) {
-	client := youtube.Client{}
+// Cancel cancels all calls started with package functions, use Handle to cancel a single call
+func Cancel() {
+	rootmu.Lock()
+	defer rootmu.Unlock()
 
-	video, err := client.GetVideo(videoId)
-	if err != nil {
-		return "empty", err
-	}
+	cancel()
+	ctx, cancel = context.WithCancel(context.Background())
+}
 
-	if len(video.Formats) == 0 {
-		return "empty", fmt.Errorf("no formats found")
-	}
+// root returns handle for calls started with package functions
+func root() *Handle {
+	rootmu.Lock()
+	defer rootmu.Unlock()
 
-	var uri string
-	format := video.Formats.WithAudioChannels().FindByQuality("360p")
-	if format == nil {
-		uri = video.Formats.WithAudioChannels()[0].URL
-	} else {
-		uri = format.URL
-	}
+	return &Handle{ctx, cancel}
+}
 
-	if verbose {
-		//log.Printf("BUK: Trailer url: %s\n", uri)
+// SetRanking sets score weights and preference profile used for ranking, empty strings set defaults
+func SetRanking(w string, p string) error {
+	weights, err := getWeights(w)
+	if err != nil {
+		return err
 	}
 
-	return uri, nil
-}
+	profile, err := getProfile(p)
+	if err != nil {
+		return err
+	}
 
-// Cancel cancels context
-func Cancel() {
-	cancel()
-	cancelchan <- true
+	defaultClient.SetRanking(weights, profile)
+	return nil
 }
 
 // SetVerbose sets verbosity
@@ -848,14 +766,8 @@ func TorrentWaitStartup() bool {
 
 	start := time.Now()
 	for time.Since(start).Seconds() < 10 {
-		s, err := TorrentStatus()
-		if err == nil {
-			var status TStatus
-
-			err = json.Unmarshal([]byte(s), &status)
-			if err == nil && status.State != -1 {
-				return true
-			}
+		if TorrentStatusInfo().State != -1 {
+			return true
 		}
 
 		time.Sleep(1 * time.Second)
@@ -866,19 +778,24 @@ func TorrentWaitStartup() bool {
 
 // TorrentLargestFile returns largest file from torrent
 func TorrentLargestFile() string {
-	tfiles, err := TorrentFiles()
+	file, err := TorrentLargestFileInfo()
 	if err != nil {
 		return ""
 	}
 
-	var info TLsInfo
-	err = json.Unmarshal([]byte(tfiles), &info)
+	js, err := json.MarshalIndent(file, "", "    ")
 	if err != nil {
 		return ""
 	}
 
+	return string(js[:])
+}
+
+// TorrentLargestFileInfo returns largest file from torrent
+func TorrentLargestFileInfo() (TFileInfo, error) {
+	info := TorrentFilesInfo()
 	if len(info.Files) == 0 {
-		return ""
+		return TFileInfo{}, ErrNoResults
 	}
 
 	file := info.Files[0]
@@ -888,12 +805,7 @@ func TorrentLargestFile() string {
 		}
 	}
 
-	js, err := json.MarshalIndent(file, "", "    ")
-	if err != nil {
-		return ""
-	}
-
-	return string(js[:])
+	return TFileInfo(file), nil
 }
 
 // TorrentStartup starts torrent services
@@ -927,6 +839,16 @@ func TorrentFiles() (string, error) {
 	return ttorrent.Ls()
 }
 
+// TorrentStatusInfo returns torrent status
+func TorrentStatusInfo() TStatus {
+	return TStatus(ttorrent.status())
+}
+
+// TorrentFilesInfo returns torrent files
+func TorrentFilesInfo() TLsInfo {
+	return TLsInfo(ttorrent.ls())
+}
+
 // TorStart starts tor
 func TorStart() error {
 	return ttor.Start()
@@ -0,0 +1,576 @@
+package bukanir
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Client type, holds its own state and is safe for concurrent use.
+// Client methods return typed results, package functions and Handle wrap them with JSON for gomobile.
+// Torrent and tor functions are not part of client, they control process wide session.
+type Client struct {
+	mu      sync.RWMutex
+	weights TWeights
+	profile TProfile
+}
+
+// call type, holds results of a single call
+type call struct {
+	sync.Mutex
+
+	ctx context.Context
+
+	wg, wgt, wgs sync.WaitGroup
+	throttle     chan int
+
+	torrents  []TTorrent
+	movies    []TMovie
+	subtitles []TSubtitle
+	items     []TItem
+	genres    []TGenre
+	details   TSummary
+
+	stream *stream
+
+	err error
+}
+
+// NewClient returns new client
+func NewClient() *Client {
+	return &Client{weights: defaultWeights, profile: defaultProfile}
+}
+
+// newCall returns new call
+func newCall(ctx context.Context) *call {
+	return &call{
+		ctx:       ctx,
+		torrents:  make([]TTorrent, 0),
+		movies:    make([]TMovie, 0),
+		subtitles: make([]TSubtitle, 0),
+		items:     make([]TItem, 0),
+		genres:    make([]TGenre, 0),
+	}
+}
+
+// ranking returns client weights and profile
+func (c *Client) ranking() (TWeights, TProfile) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.weights, c.profile
+}
+
+// Category returns movies by category
+func (c *Client) Category(ctx context.Context, category int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, f TFilter) ([]TMovie, error) {
+	key := strconv.Itoa(category) + f.key() + parentalKey() + localeKey()
+	if force != 1 {
+		movs := getMoviesCache(key, cacheDir, cacheDays)
+		if movs != nil {
+			return movs, nil
+		}
+	}
+
+	cl := newCall(ctx)
+
+	if tpbHost == "" {
+		tpbHost = getTpbHost()
+	}
+
+	cl.wgt.Add(1)
+	go cl.tpbTop(category, tpbHost)
+	cl.wgt.Wait()
+
+	cl.torrents = filterTorrents(cl.torrents, f)
+
+	if limit > 0 {
+		if limit > len(cl.torrents) {
+			limit = len(cl.torrents)
+		}
+		cl.torrents = cl.torrents[0:limit]
+	}
+
+	if verbose {
+		log.Printf("BUK: Total torrents: %d\n", len(cl.torrents))
+	}
+
+	if err := cl.result(len(cl.torrents)); err != nil {
+		return cl.movies, err
+	}
+
+	md := NewTmdb(ctx)
+	config, err := md.GetConfig()
+	if err != nil {
+		log.Printf("ERROR: TMDB GetConfig: %s\n", err.Error())
+		return cl.movies, metadataError(err)
+	}
+
+	cl.enrich(config)
+	cl.movies = filterMovies(cl.movies, f)
+
+	if verbose {
+		log.Printf("BUK: Total movies: %d\n", len(cl.movies))
+		logLimiters()
+	}
+
+	if err := cl.result(len(cl.movies)); err != nil {
+		return cl.movies, err
+	}
+
+	w, p := c.ranking()
+	rankMovies(cl.movies, w, p)
+	sort.Sort(BySeeders(cl.movies))
+
+	saveMoviesCache(key, cl.movies, cacheDir)
+
+	return cl.movies, nil
+}
+
+// Search returns movies by search query, sortBy is one of seeders, episodes, score, imdbRating or imdbVotes
+func (c *Client) Search(ctx context.Context, query string, limit int, force int, cacheDir string, cacheDays int64, pages int, tpbHost, eztvHost, sortBy, media string, f TFilter) ([]TMovie, error) {
+	return c.search(ctx, query, limit, force, cacheDir, cacheDays, pages, tpbHost, eztvHost, sortBy, media, f, nil)
+}
+
+// search returns movies by search query, movies are also delivered to stream if it is not nil
+func (c *Client) search(ctx context.Context, query string, limit int, force int, cacheDir string, cacheDays int64, pages int, tpbHost, eztvHost, sortBy, media string, f TFilter, s *stream) ([]TMovie, error) {
+	query = strings.TrimSpace(query)
+
+	w, p := c.ranking()
+
+	key := query + f.key() + parentalKey() + localeKey()
+	if force != 1 {
+		movs := getMoviesCache(key, cacheDir, cacheDays)
+		if movs != nil {
+			rankMovies(movs, w, p)
+			sortMovies(movs, sortBy)
+
+			if s != nil {
+				for _, m := range movs {
+					s.result(m)
+				}
+				if s.progress != nil {
+					s.progress(len(movs), len(movs))
+				}
+			}
+
+			return movs, nil
+		}
+	}
+
+	cl := newCall(ctx)
+
+	if s != nil {
+		s.weights, s.profile, s.filter = w, p, f
+		cl.stream = s
+	}
+
+	if tpbHost == "" {
+		tpbHost = getTpbHost()
+	} else {
+		if verbose {
+			log.Printf("TPB: Using host %s\n", tpbHost)
+		}
+	}
+
+	if eztvHost == "" {
+		eztvHost = getEztvHost()
+	} else {
+		if verbose {
+			log.Printf("EZTV: Using host %s\n", eztvHost)
+		}
+	}
+
+	if media == "all" {
+		cl.wgt.Add(pages + 1)
+		for n := 0; n < pages; n++ {
+			go cl.tpbSearch(query, n, tpbHost, media)
+		}
+		go cl.eztvSearch(query, eztvHost)
+		cl.wgt.Wait()
+	} else if media == "movies" {
+		cl.wgt.Add(pages)
+		for n := 0; n < pages; n++ {
+			go cl.tpbSearch(query, n, tpbHost, media)
+		}
+		cl.wgt.Wait()
+	} else if media == "episodes" {
+		cl.wgt.Add(1)
+		go cl.eztvSearch(query, eztvHost)
+		cl.wgt.Wait()
+	}
+
+	cl.torrents = filterTorrents(cl.torrents, f)
+
+	if verbose {
+		log.Printf("BUK: Total torrents: %d\n", len(cl.torrents))
+	}
+
+	if err := cl.result(len(cl.torrents)); err != nil {
+		return cl.movies, err
+	}
+
+	md := NewTmdb(ctx)
+	config, err := md.GetConfig()
+	if err != nil {
+		log.Printf("ERROR: TMDB GetConfig: %s\n", err.Error())
+		return cl.movies, metadataError(err)
+	}
+
+	cl.enrich(config)
+	cl.movies = filterMovies(cl.movies, f)
+
+	if verbose {
+		log.Printf("BUK: Total movies: %d\n", len(cl.movies))
+		logLimiters()
+	}
+
+	if err := cl.result(len(cl.movies)); err != nil {
+		return cl.movies, err
+	}
+
+	rankMovies(cl.movies, w, p)
+	sortMovies(cl.movies, sortBy)
+
+	saveMoviesCache(key, cl.movies, cacheDir)
+
+	return cl.movies, nil
+}
+
+// Group groups movies returned by Search, Category or Genre, one entry per title with all releases nested.
+// Best is the index of the release that best matches the preference profile.
+func (c *Client) Group(movs []TMovie, p TProfile) []TGroup {
+	return groupMovies(movs, p)
+}
+
+// Summary returns movie summary
+func (c *Client) Summary(ctx context.Context, id int, category int, season int, episode int) (TSummary, error) {
+	cl := newCall(ctx)
+
+	cl.wg.Add(1)
+	go cl.tmdbSummary(id, category, season, episode)
+	cl.wg.Wait()
+
+	if cl.details.Id == 0 {
+		return cl.details, cl.result(0)
+	}
+
+	return cl.details, nil
+}
+
+// Subtitle returns movie subtitles
+func (c *Client) Subtitle(ctx context.Context, movie string, year string, release string, language string, category int, season int, episode int, imdbID string) ([]TSubtitle, error) {
+	cl := newCall(ctx)
+	language = strings.ToLower(language)
+
+	cl.wgs.Add(3)
+	go cl.podnapisi(movie, year, release, language, category, season, episode)
+	go cl.opensubtitles(movie, imdbID, year, release, language, category, season, episode)
+	go cl.subscene(movie, year, release, language, category, season, episode)
+	cl.wgs.Wait()
+
+	if len(cl.subtitles) == 0 && language != "english" && ctx.Err() == nil {
+		if verbose {
+			log.Printf("SUB: No %s subtitles, trying with english\n", language)
+		}
+
+		cl.wgs.Add(3)
+		go cl.podnapisi(movie, year, release, "english", category, season, episode)
+		go cl.opensubtitles(movie, imdbID, year, release, "english", category, season, episode)
+		go cl.subscene(movie, year, release, "english", category, season, episode)
+		cl.wgs.Wait()
+	}
+
+	if verbose {
+		log.Printf("SUB: Total subtitles: %d\n", len(cl.subtitles))
+	}
+
+	sort.Sort(ByScore(cl.subtitles))
+
+	return cl.subtitles, cl.result(len(cl.subtitles))
+}
+
+// UnzipSubtitle unzip subtitle, returns path of extracted subtitle
+func (c *Client) UnzipSubtitle(ctx context.Context, url string, dest string) (string, error) {
+	res, err := getResponse(ctx, url)
+	if err != nil {
+		log.Printf("ERROR: getResponse %s: %v\n", url, err.Error())
+		return "", err
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		log.Printf("ERROR: ReadAll: %s\n", err.Error())
+		return "", err
+	}
+	defer res.Body.Close()
+
+	z, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		log.Printf("ERROR: NewReader: %s\n", err.Error())
+		return "", err
+	}
+
+	exts := []string{".srt", ".ass", ".ssa"}
+	if runtime.GOOS != "android" {
+		exts = append(exts, ".sub")
+	}
+
+	for _, f := range z.File {
+		ext := filepath.Ext(f.Name)
+		for _, e := range exts {
+			if e == ext {
+				dst, err := os.Create(filepath.Join(dest, f.Name))
+				if err != nil {
+					return "", err
+				}
+
+				src, err := f.Open()
+				if err != nil {
+					return "", err
+				}
+
+				_, err = io.Copy(dst, src)
+				if err != nil {
+					return "", err
+				}
+
+				_ = dst.Close()
+				_ = src.Close()
+
+				return filepath.Join(dest, f.Name), nil
+			}
+		}
+	}
+
+	return "", ErrNoResults
+}
+
+// AutoComplete completes search queries
+func (c *Client) AutoComplete(ctx context.Context, query string, limit int) ([]TItem, error) {
+	cl := newCall(ctx)
+
+	cl.wg.Add(1)
+	go cl.tmdbAutoComplete(query)
+	cl.wg.Wait()
+
+	if limit > 0 {
+		if limit > len(cl.items) {
+			limit = len(cl.items)
+		}
+		cl.items = cl.items[0:limit]
+	}
+
+	return cl.items, cl.result(len(cl.items))
+}
+
+// Popular returns popular movies
+func (c *Client) Popular(ctx context.Context) ([]TItem, error) {
+	cl := newCall(ctx)
+
+	cl.wg.Add(1)
+	go cl.tmdbPopular()
+	cl.wg.Wait()
+
+	return cl.items, cl.result(len(cl.items))
+}
+
+// TopRated returns top rated movies
+func (c *Client) TopRated(ctx context.Context) ([]TItem, error) {
+	cl := newCall(ctx)
+
+	cl.wg.Add(1)
+	go cl.tmdbTopRated()
+	cl.wg.Wait()
+
+	return cl.items, cl.result(len(cl.items))
+}
+
+// Genres returns all genres
+func (c *Client) Genres(ctx context.Context) ([]TGenre, error) {
+	cl := newCall(ctx)
+
+	cl.wg.Add(1)
+	go cl.tmdbGetGenres()
+	cl.wg.Wait()
+
+	return cl.genres, cl.result(len(cl.genres))
+}
+
+// Genre returns movies by genre
+func (c *Client) Genre(ctx context.Context, id int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, f TFilter) ([]TMovie, error) {
+	key := "genre" + strconv.Itoa(id) + f.key() + parentalKey() + localeKey()
+	if force != 1 {
+		movs := getMoviesCache(key, cacheDir, cacheDays)
+		if movs != nil {
+			return movs, nil
+		}
+	}
+
+	cl := newCall(ctx)
+
+	if tpbHost == "" {
+		tpbHost = getTpbHost()
+	} else {
+		if verbose {
+			log.Printf("TPB: Using host %s\n", tpbHost)
+		}
+	}
+
+	cl.wg.Add(1)
+	go cl.tmdbByGenre(id, limit, tpbHost, f)
+	cl.wg.Wait()
+
+	return c.finish(cl, key, cacheDir, f)
+}
+
+// Cast returns movies by cast
+func (c *Client) Cast(ctx context.Context, id int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string) ([]TMovie, error) {
+	key := "cast" + strconv.Itoa(id) + parentalKey() + localeKey()
+	if force != 1 {
+		movs := getMoviesCache(key, cacheDir, cacheDays)
+		if movs != nil {
+			return movs, nil
+		}
+	}
+
+	cl := newCall(ctx)
+
+	if tpbHost == "" {
+		tpbHost = getTpbHost()
+	} else {
+		if verbose {
+			log.Printf("TPB: Using host %s\n", tpbHost)
+		}
+	}
+
+	cl.wg.Add(1)
+	go cl.tmdbWithCast(id, limit, tpbHost)
+	cl.wg.Wait()
+
+	return c.finish(cl, key, cacheDir, defaultFilter)
+}
+
+// Crew returns movies by crew
+func (c *Client) Crew(ctx context.Context, id int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string) ([]TMovie, error) {
+	key := "crew" + strconv.Itoa(id) + parentalKey() + localeKey()
+	if force != 1 {
+		movs := getMoviesCache(key, cacheDir, cacheDays)
+		if movs != nil {
+			return movs, nil
+		}
+	}
+
+	cl := newCall(ctx)
+
+	if tpbHost == "" {
+		tpbHost = getTpbHost()
+	} else {
+		if verbose {
+			log.Printf("TPB: Using host %s\n", tpbHost)
+		}
+	}
+
+	cl.wg.Add(1)
+	go cl.tmdbWithCrew(id, limit, tpbHost)
+	cl.wg.Wait()
+
+	return c.finish(cl, key, cacheDir, defaultFilter)
+}
+
+// finish rates, filters, ranks, sorts and caches movies of a finished call
+func (c *Client) finish(cl *call, key string, cacheDir string, f TFilter) ([]TMovie, error) {
+	cl.setImdbRatings(cl.movies)
+	cl.movies = filterMovies(cl.movies, f)
+	cl.movies = cl.parental(cl.movies)
+
+	return c.rank(cl, key, cacheDir, true)
+}
+
+// rank ranks and caches already filtered movies of a finished call, movies are sorted by seeders or keep their order
+func (c *Client) rank(cl *call, key string, cacheDir string, bySeeders bool) ([]TMovie, error) {
+	if verbose {
+		log.Printf("BUK: Total movies: %d\n", len(cl.movies))
+		logLimiters()
+	}
+
+	if err := cl.result(len(cl.movies)); err != nil {
+		return cl.movies, err
+	}
+
+	w, p := c.ranking()
+	rankMovies(cl.movies, w, p)
+	if bySeeders {
+		sort.Sort(BySeeders(cl.movies))
+	}
+
+	saveMoviesCache(key, cl.movies, cacheDir)
+
+	return cl.movies, nil
+}
+
+// SetRanking sets score weights and preference profile used for ranking
+func (c *Client) SetRanking(w TWeights, p TProfile) {
+	if w.ProviderTrust == nil {
+		w.ProviderTrust = defaultWeights.ProviderTrust
+	}
+
+	c.mu.Lock()
+	c.weights = w
+	c.profile = p
+	c.mu.Unlock()
+}
+
+// sortMovies sorts movies by seeders, episodes or score
+func sortMovies(movs []TMovie, sortBy string) {
+	if sortBy == "seeders" || sortBy == "" {
+		sort.Sort(BySeeders(movs))
+	} else if sortBy == "episodes" {
+		s := BySeasonEpisode{}
+		s.Sort(movs)
+	} else if sortBy == "score" {
+		sort.Sort(ByRank(movs))
+	} else if sortBy == "imdbRating" {
+		sort.Stable(ByImdbRating(movs))
+	} else if sortBy == "imdbVotes" {
+		sort.Stable(ByImdbVotes(movs))
+	}
+}
+
+// getMoviesCache returns cached movies, nil if there is no cache
+func getMoviesCache(key string, cacheDir string, days int64) []TMovie {
+	cache := getCache(key, cacheDir, days)
+	if cache == nil {
+		return nil
+	}
+
+	var movs []TMovie
+	err := json.Unmarshal(cache, &movs)
+	if err != nil {
+		log.Printf("ERROR: Unmarshal cache: %s\n", err.Error())
+		return nil
+	}
+
+	return movs
+}
+
+// saveMoviesCache saves movies to cache
+func saveMoviesCache(key string, movs []TMovie, cacheDir string) {
+	js, err := json.MarshalIndent(movs, "", "    ")
+	if err != nil {
+		log.Printf("ERROR: Marshal cache: %s\n", err.Error())
+		return
+	}
+
+	saveCache(key, js, cacheDir)
+}
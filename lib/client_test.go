@@ -0,0 +1,120 @@
+package bukanir
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestClientConcurrent(t *testing.T) {
+	cacheDir, err := ioutil.TempDir(os.TempDir(), "bukanir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	c := NewClient()
+	ctx := context.Background()
+
+	var w sync.WaitGroup
+	results := make([]int, 4)
+	errs := make([]error, 4)
+
+	w.Add(4)
+	go func() {
+		defer w.Done()
+		movs, err := c.Search(ctx, tName, 0, 1, cacheDir, 0, 1, "", "", "seeders", "movies", DefaultFilter())
+		results[0], errs[0] = len(movs), err
+	}()
+	go func() {
+		defer w.Done()
+		movs, err := c.Genre(ctx, 28, 5, 1, cacheDir, 0, "", DefaultFilter())
+		results[1], errs[1] = len(movs), err
+	}()
+	go func() {
+		defer w.Done()
+		items, err := c.Popular(ctx)
+		results[2], errs[2] = len(items), err
+	}()
+	go func() {
+		defer w.Done()
+		genres, err := c.Genres(ctx)
+		results[3], errs[3] = len(genres), err
+	}()
+	w.Wait()
+
+	for n, r := range results {
+		if errs[n] != nil {
+			t.Errorf("%d: %s", n, errs[n].Error())
+			continue
+		}
+
+		if r == 0 {
+			t.Errorf("%d: no results", n)
+		}
+	}
+}
+
+func TestClientCancel(t *testing.T) {
+	cacheDir, err := ioutil.TempDir(os.TempDir(), "bukanir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	c := NewClient()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = c.Search(ctx, tName, 0, 1, cacheDir, 0, 1, TpbHosts[0], EztvHosts[0], "seeders", "all", DefaultFilter())
+	if err != context.Canceled {
+		t.Errorf("got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestToJSON(t *testing.T) {
+	js, err := toJSON(make([]TMovie, 0), ErrNoResults)
+	if err != nil || js != "[]" {
+		t.Errorf("got %q, %v, want empty list", js, err)
+	}
+
+	js, err = toJSON(make([]TMovie, 0), ErrProviderUnavailable)
+	if err != ErrProviderUnavailable || js != "empty" {
+		t.Errorf("got %q, %v, want empty", js, err)
+	}
+}
+
+func TestSearchStream(t *testing.T) {
+	cacheDir, err := ioutil.TempDir(os.TempDir(), "bukanir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	c := NewClient()
+	results := make(chan TMovie)
+
+	var movs []TMovie
+	done := make(chan struct{})
+	go func() {
+		movs, err = c.SearchStream(context.Background(), tName, 0, 1, cacheDir, 0, 1, "", "", "seeders", "movies", DefaultFilter(), results)
+		close(done)
+	}()
+
+	n := 0
+	for range results {
+		n++
+	}
+	<-done
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if n == 0 || n != len(movs) {
+		t.Errorf("got %d streamed movies, want %d", n, len(movs))
+	}
+}
@@ -0,0 +1,127 @@
+package bukanir
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Collection returns all parts of movie collection in release order, each with the best available torrent by preference profile
+func (c *Client) Collection(ctx context.Context, id int, tpbHost string, f TFilter) (TCollection, error) {
+	var collection TCollection
+	md := NewTmdb(ctx)
+
+	config, err := md.GetConfig()
+	if err != nil {
+		log.Printf("ERROR: TMDB GetConfig: %s\n", err.Error())
+		return collection, metadataError(err)
+	}
+
+	res, err := md.GetCollection(strconv.Itoa(id))
+	if err != nil {
+		log.Printf("ERROR: GetCollection: %v\n", err.Error())
+		return collection, metadataError(err)
+	}
+
+	if len(res.Parts) == 0 {
+		return collection, ErrNoResults
+	}
+
+	if tpbHost == "" {
+		tpbHost = getTpbHost()
+	}
+
+	// Unreleased parts without date are last
+	sort.SliceStable(res.Parts, func(i, j int) bool {
+		a, b := res.Parts[i].Release_date, res.Parts[j].Release_date
+		if a == "" || b == "" {
+			return b == "" && a != ""
+		}
+		return a < b
+	})
+
+	collection = TCollection{
+		res.Id,
+		res.Name,
+		res.Overview,
+		imageUrl(config, config.Images.Poster_sizes, 1, res.Poster_path),
+		imageUrl(config, config.Images.Backdrop_sizes, 1, res.Backdrop_path),
+		make([]TPart, len(res.Parts)),
+		md.stale,
+	}
+
+	_, p := c.ranking()
+	pb := NewTpb(ctx, tpbHost)
+
+	var wg sync.WaitGroup
+	for n, r := range res.Parts {
+		collection.Parts[n] = TPart{
+			r.Id,
+			r.Title,
+			getYear(r.Release_date),
+			r.Overview,
+			imageUrl(config, config.Images.Poster_sizes, 1, r.Poster_path),
+			nil,
+		}
+
+		wg.Add(1)
+		go func(part *TPart, r tmdbResult) {
+			defer wg.Done()
+
+			rs := partReleases(pb, md.englishTitle(r, false), r, f)
+			if len(rs) > 0 {
+				best := rs[p.best(rs)]
+				part.Torrent = &best
+			}
+		}(&collection.Parts[n], r)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return collection, ctx.Err()
+	}
+
+	return collection, nil
+}
+
+// partReleases returns releases of collection part searched by query, matched by title and year
+func partReleases(pb *tpb, query string, r tmdbResult, f TFilter) []TRelease {
+	releases := make([]TRelease, 0)
+	if r.Release_date == "" {
+		return releases
+	}
+
+	results, err := pb.Search(query, 0, "201,207")
+	if err != nil {
+		log.Printf("ERROR: TPB Search: %s\n", err.Error())
+		return releases
+	}
+
+	for _, t := range filterTorrents(results, f) {
+		if getTitle(query) != t.FormattedTitle && getTitle(r.Title) != t.FormattedTitle && getTitle(r.Original_title) != t.FormattedTitle {
+			continue
+		}
+
+		if yearSimilarity(getYear(r.Release_date), t.Year) < 0.7 {
+			continue
+		}
+
+		rl := TRelease{
+			t.Title,
+			t.MagnetLink,
+			t.Size,
+			t.SizeHuman,
+			t.Seeders,
+			getQuality(t.Title),
+			t.Provider,
+			parseRelease(t.Title),
+		}
+		releases = append(releases, rl)
+	}
+
+	sort.Stable(ByRSeeders(releases))
+
+	return releases
+}
@@ -7,6 +7,9 @@ const (
 	tmdbApiUrl = "http://api.themoviedb.org/3"
 	tmdbApiKey = "YOUR_API_KEY"
 
+	// tmdbApiKeyPlaceholder is api key of source tree, it is treated as unset
+	tmdbApiKeyPlaceholder = "YOUR_API_KEY"
+
 	opensubsUser      = ""
 	opensubsPassword  = ""
 	opensubsUserAgent = "OSTestUserAgent"
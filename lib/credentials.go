@@ -0,0 +1,164 @@
+package bukanir
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+)
+
+// TCredentials type
+type TCredentials struct {
+	TmdbApiKey        string `json:"tmdbApiKey"`
+	TmdbToken         string `json:"tmdbToken"`
+	OpensubsUser      string `json:"opensubsUser"`
+	OpensubsPassword  string `json:"opensubsPassword"`
+	OpensubsUserAgent string `json:"opensubsUserAgent"`
+}
+
+// Environment variables with credentials
+const (
+	envTmdbApiKey        = "BUKANIR_TMDB_API_KEY"
+	envTmdbToken         = "BUKANIR_TMDB_TOKEN"
+	envOpensubsUser      = "BUKANIR_OPENSUBS_USER"
+	envOpensubsPassword  = "BUKANIR_OPENSUBS_PASSWORD"
+	envOpensubsUserAgent = "BUKANIR_OPENSUBS_USER_AGENT"
+)
+
+// Credentials from config file and SetCredentials, runtime credentials take precedence over
+// environment, environment over config file, and config file over compiled-in defaults
+var (
+	credsMu      sync.RWMutex
+	fileCreds    TCredentials
+	runtimeCreds TCredentials
+)
+
+// SetCredentials sets credentials from json string, empty fields are not changed
+func SetCredentials(js string) error {
+	var c TCredentials
+	err := json.Unmarshal([]byte(js), &c)
+	if err != nil {
+		return err
+	}
+
+	credsMu.Lock()
+	defer credsMu.Unlock()
+
+	runtimeCreds = merge(c, runtimeCreds)
+	return nil
+}
+
+// LoadCredentials loads credentials from json config file
+func LoadCredentials(file string) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	var c TCredentials
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+
+	credsMu.Lock()
+	defer credsMu.Unlock()
+
+	fileCreds = c
+	return nil
+}
+
+// ValidateCredentials checks that TMDB credentials are set and accepted
+func ValidateCredentials() error {
+	c := getCredentials()
+	if c.TmdbToken == "" && c.TmdbApiKey == "" {
+		return fmt.Errorf("%w: TMDB api key is not set, use %s, %s, config file or SetCredentials", ErrInvalidCredentials, envTmdbApiKey, envTmdbToken)
+	}
+
+	md := NewTmdb(context.Background())
+	uri := md.prepare(fmt.Sprintf("%s/configuration?api_key=%s", tmdbApiUrl, md.Api_key))
+
+	res, err := getResponse(context.Background(), uri)
+	if err != nil {
+		if errors.Is(err, errUnauthorized) {
+			return fmt.Errorf("%w: TMDB api key or token was rejected", ErrInvalidCredentials)
+		}
+		return err
+	}
+	res.Body.Close()
+
+	return nil
+}
+
+// getCredentials returns credentials, placeholder api key is returned as empty
+func getCredentials() TCredentials {
+	var c TCredentials
+	for _, l := range layers() {
+		c = merge(c, l)
+	}
+
+	if c.TmdbApiKey == tmdbApiKeyPlaceholder {
+		c.TmdbApiKey = ""
+	}
+
+	return c
+}
+
+// layers returns credentials from all sources, in order of precedence
+func layers() []TCredentials {
+	env := TCredentials{
+		os.Getenv(envTmdbApiKey),
+		os.Getenv(envTmdbToken),
+		os.Getenv(envOpensubsUser),
+		os.Getenv(envOpensubsPassword),
+		os.Getenv(envOpensubsUserAgent),
+	}
+
+	defaults := TCredentials{
+		tmdbApiKey,
+		"",
+		opensubsUser,
+		opensubsPassword,
+		opensubsUserAgent,
+	}
+
+	credsMu.RLock()
+	defer credsMu.RUnlock()
+
+	return []TCredentials{runtimeCreds, env, fileCreds, defaults}
+}
+
+// merge returns credentials with empty fields taken from fallback
+func merge(c TCredentials, fallback TCredentials) TCredentials {
+	pick := func(s, f string) string {
+		if s != "" {
+			return s
+		}
+		return f
+	}
+
+	return TCredentials{
+		pick(c.TmdbApiKey, fallback.TmdbApiKey),
+		pick(c.TmdbToken, fallback.TmdbToken),
+		pick(c.OpensubsUser, fallback.OpensubsUser),
+		pick(c.OpensubsPassword, fallback.OpensubsPassword),
+		pick(c.OpensubsUserAgent, fallback.OpensubsUserAgent),
+	}
+}
+
+// redact replaces secrets from all credential sources in string
+func redact(s string) string {
+	for _, c := range layers() {
+		for _, secret := range []string{c.TmdbApiKey, c.TmdbToken, c.OpensubsPassword} {
+			if secret != "" {
+				s = strings.Replace(s, secret, "xxx", -1)
+			}
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,96 @@
+package bukanir
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "bukanir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "credentials.json")
+	err = ioutil.WriteFile(file, []byte(`{"tmdbApiKey": "filekey", "opensubsUser": "fileuser"}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = LoadCredentials(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		fileCreds = TCredentials{}
+		runtimeCreds = TCredentials{}
+	}()
+
+	os.Setenv(envTmdbApiKey, "envkey")
+	defer os.Unsetenv(envTmdbApiKey)
+
+	c := getCredentials()
+	if c.TmdbApiKey != "envkey" || c.OpensubsUser != "fileuser" || c.OpensubsUserAgent != opensubsUserAgent {
+		t.Errorf("unexpected credentials %+v", c)
+	}
+
+	err = SetCredentials(`{"tmdbToken": "secrettoken"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s := redact("key filekey envkey token secrettoken"); s != "key xxx xxx token xxx" {
+		t.Errorf("got %s, secrets were not redacted", s)
+	}
+
+	os.Setenv(envTmdbApiKey, tmdbApiKey)
+	fileCreds = TCredentials{}
+	runtimeCreds = TCredentials{}
+
+	if err := ValidateCredentials(); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("got %v, want %v", err, ErrInvalidCredentials)
+	}
+}
+
+func TestPrepare(t *testing.T) {
+	md := NewTmdb(context.Background())
+	md.Api_key = ""
+
+	if uri := md.prepare(tmdbApiUrl + "/configuration?api_key="); uri != tmdbApiUrl+"/configuration" {
+		t.Errorf("got %s", uri)
+	}
+
+	if uri := md.prepare(tmdbApiUrl + "/search/movie?api_key=&query=alien"); uri != tmdbApiUrl+"/search/movie?query=alien" {
+		t.Errorf("got %s", uri)
+	}
+
+	md.Api_key, md.token = "key", "token"
+	if uri := md.prepare(tmdbApiUrl + "/search/movie?api_key=key&query=alien"); uri != tmdbApiUrl+"/search/movie?query=alien" {
+		t.Errorf("got %s, api key is not removed with token", uri)
+	}
+}
+
+func TestTokenOnly(t *testing.T) {
+	err := SetCredentials(`{"tmdbToken": "secrettoken"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		runtimeCreds = TCredentials{}
+	}()
+
+	if c := getCredentials(); c.TmdbApiKey != "" || c.TmdbToken != "secrettoken" {
+		t.Errorf("unexpected credentials %+v, placeholder api key should be unset", c)
+	}
+
+	md := NewTmdb(context.Background())
+	if uri := md.prepare(fmt.Sprintf("%s/configuration?api_key=%s", tmdbApiUrl, md.Api_key)); uri != tmdbApiUrl+"/configuration" {
+		t.Errorf("got %s", uri)
+	}
+}
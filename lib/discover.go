@@ -0,0 +1,189 @@
+package bukanir
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Maximum number of TMDB pages walked by discover, limits calls for filters that match few torrents
+const discoverPages = 20
+
+// Number of TMDB pages walked by discover without limit
+const discoverDefaultPages = 5
+
+// Discover returns movies or tv shows matching discover query that have a torrent, TMDB pages are
+// walked until limit titles are found
+func (c *Client) Discover(ctx context.Context, d TDiscover, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, f TFilter) ([]TMovie, error) {
+	js, _ := json.Marshal(d)
+	key := "discover" + string(js) + strconv.Itoa(limit) + f.key() + parentalKey() + localeKey()
+	if force != 1 {
+		movs := getMoviesCache(key, cacheDir, cacheDays)
+		if movs != nil {
+			return movs, nil
+		}
+	}
+
+	cl := newCall(ctx)
+
+	if tpbHost == "" {
+		tpbHost = getTpbHost()
+	} else {
+		if verbose {
+			log.Printf("TPB: Using host %s\n", tpbHost)
+		}
+	}
+
+	cl.wg.Add(1)
+	go cl.tmdbDiscover(d, limit, tpbHost, f)
+	cl.wg.Wait()
+
+	// Movies are filtered per page, explicit sort order of TMDB is kept
+	return c.rank(cl, key, cacheDir, d.SortBy == "")
+}
+
+// tmdbDiscover TMDB discover, only titles with a torrent are kept
+func (c *call) tmdbDiscover(d TDiscover, limit int, tpbHost string, f TFilter) {
+	defer func() {
+		c.wg.Done()
+		if r := recover(); r != nil {
+			log.Print("TMDB: Recovered in tmdbDiscover")
+		}
+	}()
+
+	md := NewTmdb(c.ctx)
+	config, err := md.GetConfig()
+	if err != nil {
+		log.Printf("ERROR: TMDB GetConfig: %s\n", err.Error())
+		c.fail(metadataError(err))
+		return
+	}
+
+	if len(config.Images.Poster_sizes) < 5 {
+		return
+	}
+
+	media, query := d.query()
+
+	pages := discoverPages
+	if limit <= 0 {
+		pages = discoverDefaultPages
+	}
+
+	kept := make([]TMovie, 0)
+	defer func() {
+		c.movies = kept
+	}()
+
+	for n := 1; n <= pages; n++ {
+		if c.ctx.Err() != nil {
+			return
+		}
+
+		mp, err := md.Discover(media, query, n)
+		if err != nil {
+			log.Printf("ERROR: Discover: %v\n", err.Error())
+			c.fail(metadataError(err))
+			return
+		}
+
+		if media == "tv" {
+			for i := range mp.Results {
+				mp.Results[i].Media_type = "tv"
+			}
+		}
+
+		start := len(c.movies)
+		c.withTorrents(mp.Results, tpbHost, f, config)
+
+		// Movies of page are appended concurrently, restore TMDB order and filter them before they count to limit
+		movs := append([]TMovie{}, c.movies[start:]...)
+		order := make(map[int]int)
+		for i, r := range mp.Results {
+			order[r.Id] = i
+		}
+		sort.SliceStable(movs, func(i, j int) bool {
+			return order[movs[i].Id] < order[movs[j].Id]
+		})
+
+		c.setImdbRatings(movs)
+		movs = filterMovies(movs, f)
+		movs = c.parental(movs)
+
+		kept = append(kept, movs...)
+
+		if limit > 0 && len(kept) >= limit {
+			kept = kept[0:limit]
+			break
+		}
+
+		if n >= mp.Total_pages {
+			break
+		}
+	}
+}
+
+// query returns media and TMDB discover query parameters
+func (d TDiscover) query() (string, url.Values) {
+	media := "movie"
+	date := "primary_release_date"
+	if d.Media == "tv" {
+		media = "tv"
+		date = "first_air_date"
+	}
+
+	q := url.Values{}
+
+	if len(d.Genres) > 0 {
+		genres := make([]string, 0)
+		for _, g := range d.Genres {
+			genres = append(genres, strconv.Itoa(g))
+		}
+
+		sep := ","
+		if d.AnyGenre {
+			sep = "|"
+		}
+		q.Set("with_genres", strings.Join(genres, sep))
+	}
+
+	if d.YearFrom > 0 {
+		q.Set(date+".gte", strconv.Itoa(d.YearFrom)+"-01-01")
+	}
+
+	if d.YearTo > 0 {
+		q.Set(date+".lte", strconv.Itoa(d.YearTo)+"-12-31")
+	}
+
+	if d.MinVote > 0 {
+		q.Set("vote_average.gte", strconv.FormatFloat(d.MinVote, 'f', -1, 64))
+	}
+
+	if d.MinVoteCount > 0 {
+		q.Set("vote_count.gte", strconv.Itoa(d.MinVoteCount))
+	}
+
+	if d.Language != "" {
+		q.Set("with_original_language", d.Language)
+	}
+
+	if d.MinRuntime > 0 {
+		q.Set("with_runtime.gte", strconv.Itoa(d.MinRuntime))
+	}
+
+	if d.MaxRuntime > 0 {
+		q.Set("with_runtime.lte", strconv.Itoa(d.MaxRuntime))
+	}
+
+	sortBy := d.SortBy
+	if sortBy == "" {
+		sortBy = "popularity.desc"
+	}
+	q.Set("sort_by", sortBy)
+
+	return media, q
+}
@@ -0,0 +1,27 @@
+package bukanir
+
+import (
+	"testing"
+)
+
+func TestDiscoverQuery(t *testing.T) {
+	media, q := TDiscover{Genres: []int{28, 878}, YearFrom: 2010, MinVote: 7.5}.query()
+	if media != "movie" {
+		t.Errorf("media %q, want movie", media)
+	}
+
+	want := "primary_release_date.gte=2010-01-01&sort_by=popularity.desc&vote_average.gte=7.5&with_genres=28%2C878"
+	if q.Encode() != want {
+		t.Errorf("query %q, want %q", q.Encode(), want)
+	}
+
+	media, q = TDiscover{Media: "tv", Genres: []int{18, 35}, AnyGenre: true, YearTo: 2020, SortBy: "vote_average.desc"}.query()
+	if media != "tv" {
+		t.Errorf("media %q, want tv", media)
+	}
+
+	want = "first_air_date.lte=2020-12-31&sort_by=vote_average.desc&with_genres=18%7C35"
+	if q.Encode() != want {
+		t.Errorf("query %q, want %q", q.Encode(), want)
+	}
+}
@@ -0,0 +1,70 @@
+package bukanir
+
+import (
+	"log"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// lookupKey returns TMDB lookup key for torrent, normalized title and year for movies, title and season for shows
+func lookupKey(t TTorrent) string {
+	title := strings.ToLower(strings.Join(strings.Fields(t.FormattedTitle), " "))
+
+	if t.Category == CategoryTV || t.Category == CategoryHDTV {
+		return "tv:" + title + ":" + strconv.Itoa(t.Season)
+	}
+
+	return "movie:" + title + ":" + t.Year
+}
+
+// groupTorrents groups torrents by lookup key, groups are kept in order of their first torrent
+func groupTorrents(torrents []TTorrent) [][]TTorrent {
+	groups := make([][]TTorrent, 0)
+	index := make(map[string]int)
+
+	for _, t := range torrents {
+		key := lookupKey(t)
+		if n, ok := index[key]; ok {
+			groups[n] = append(groups[n], t)
+			continue
+		}
+
+		index[key] = len(groups)
+		groups = append(groups, []TTorrent{t})
+	}
+
+	return groups
+}
+
+// enrich resolves call torrents to TMDB movies, one lookup per group of torrents
+func (c *call) enrich(config *tmdbConfig) {
+	groups := groupTorrents(c.torrents)
+
+	if verbose {
+		log.Printf("TMDB: %d lookups for %d torrents\n", len(groups), len(c.torrents))
+	}
+
+	if c.stream != nil {
+		c.stream.total = len(c.torrents)
+	}
+
+	c.throttle = make(chan int, 3*runtime.NumCPU())
+
+	for _, g := range groups {
+		if c.ctx.Err() != nil {
+			break
+		}
+
+		c.throttle <- 1
+		c.wg.Add(1)
+
+		if g[0].Category == CategoryTV || g[0].Category == CategoryHDTV {
+			go c.tmdbSearchTv(g, config)
+		} else {
+			go c.tmdbSearchMovie(g, config)
+		}
+	}
+
+	c.wg.Wait()
+}
@@ -0,0 +1,29 @@
+package bukanir
+
+import (
+	"testing"
+)
+
+func TestGroupTorrents(t *testing.T) {
+	torrents := []TTorrent{
+		{FormattedTitle: "Barbie", Year: "2023", Category: CategoryHDmovies},
+		{FormattedTitle: "barbie ", Year: "2023", Category: CategoryMovies},
+		{FormattedTitle: "Barbie", Year: "1959", Category: CategoryMovies},
+		{FormattedTitle: "The Bear", Season: 2, Category: CategoryTV},
+		{FormattedTitle: "The  Bear", Season: 2, Category: CategoryHDTV},
+		{FormattedTitle: "The Bear", Season: 1, Category: CategoryTV},
+	}
+
+	groups := groupTorrents(torrents)
+
+	want := []int{2, 1, 2, 1}
+	if len(groups) != len(want) {
+		t.Fatalf("got %d groups, want %d", len(groups), len(want))
+	}
+
+	for n, g := range groups {
+		if len(g) != want[n] {
+			t.Errorf("%d: got %d torrents, want %d", n, len(g), want[n])
+		}
+	}
+}
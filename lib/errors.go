@@ -0,0 +1,82 @@
+package bukanir
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Errors returned by typed functions, use errors.Is to check for them
+var (
+	// ErrNoResults is returned when call finished without results
+	ErrNoResults = errors.New("no results")
+	// ErrProviderUnavailable is returned when torrent provider can not be reached
+	ErrProviderUnavailable = errors.New("provider unavailable")
+	// ErrRateLimited is returned when host keeps responding with 429 after retries
+	ErrRateLimited = errors.New("rate limited")
+	// ErrMetadataTimeout is returned when TMDB request times out
+	ErrMetadataTimeout = errors.New("metadata timeout")
+	// ErrInvalidCredentials is returned by ValidateCredentials when credentials are missing or rejected
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrInvalidPin is returned when parental controls PIN does not match
+	ErrInvalidPin = errors.New("invalid pin")
+)
+
+// Errors that mark responses in getResponse
+var (
+	errTimeout      = errors.New("timeout")
+	errUnauthorized = errors.New("unauthorized")
+)
+
+// providerError wraps torrent provider error
+func providerError(provider string, err error) error {
+	if isCanceled(err) || errors.Is(err, ErrRateLimited) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s: %v", ErrProviderUnavailable, provider, err)
+}
+
+// metadataError wraps TMDB error
+func metadataError(err error) error {
+	if errors.Is(err, errTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %v", ErrMetadataTimeout, err)
+	}
+
+	return err
+}
+
+// isCanceled checks if error is caused by canceled context
+func isCanceled(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
+
+// fail records first error of a call
+func (c *call) fail(err error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+// result returns error for a call that finished with n results
+func (c *call) result(n int) error {
+	if err := c.ctx.Err(); err != nil {
+		return err
+	}
+
+	if n > 0 {
+		return nil
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+
+	return ErrNoResults
+}
@@ -1,6 +1,7 @@
 package bukanir
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -17,11 +18,12 @@ import (
 type eztv struct {
 	Host  string
 	Query string
+	ctx   context.Context
 }
 
 // NewEztv returns new eztv
-func NewEztv(host string) *eztv {
-	return &eztv{host, ""}
+func NewEztv(ctx context.Context, host string) *eztv {
+	return &eztv{host, "", ctx}
 }
 
 // Search returns torrents for query
@@ -35,7 +37,7 @@ func (t *eztv) Search(query string) ([]TTorrent, error) {
 		log.Printf("EZTV: GET %s\n", uri)
 	}
 
-	doc, err := getDocument(uri)
+	doc, err := getDocument(t.ctx, uri)
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +62,7 @@ func (t *eztv) getTorrents(doc *goquery.Document) ([]TTorrent, error) {
 	}
 
 	var w sync.WaitGroup
+	var mu sync.Mutex
 	parseHTML := func(i int, s *goquery.Selection) {
 		defer w.Done()
 
@@ -74,7 +77,7 @@ func (t *eztv) getTorrents(doc *goquery.Document) ([]TTorrent, error) {
 
 		formattedTitle := getTitle(title)
 
-		if seeders == 0 || formattedTitle == "" || !strings.HasPrefix(magnet, "magnet:?") {
+		if formattedTitle == "" || !strings.HasPrefix(magnet, "magnet:?") {
 			return
 		}
 
@@ -87,7 +90,7 @@ func (t *eztv) getTorrents(doc *goquery.Document) ([]TTorrent, error) {
 		size, _ = humanize.ParseBytes(td3.Text())
 		sizeHuman = humanize.IBytes(size)
 
-		if size == 0 || size > 5120*1024*1024 {
+		if size == 0 {
 			return
 		}
 
@@ -109,9 +112,14 @@ func (t *eztv) getTorrents(doc *goquery.Document) ([]TTorrent, error) {
 			CategoryTV,
 			season,
 			episode,
+			"eztv",
+			0,
+			0,
 		}
 
+		mu.Lock()
 		results = append(results, t)
+		mu.Unlock()
 	}
 
 	w.Add(trs.Length())
@@ -1,11 +1,12 @@
 package bukanir
 
 import (
+	"context"
 	"testing"
 )
 
 func TestSearchEztv(t *testing.T) {
-	ez := NewEztv(getEztvHost())
+	ez := NewEztv(context.Background(), getEztvHost())
 
 	results, err := ez.Search(teName)
 	if err != nil {
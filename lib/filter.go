@@ -0,0 +1,143 @@
+package bukanir
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Default filter, used when no filter is given
+var defaultFilter = TFilter{
+	MaxSize:    5120 * 1024 * 1024,
+	MinSeeders: 1,
+}
+
+// getFilter returns filter from json string, empty string returns default filter and missing fields are default
+func getFilter(js string) (TFilter, error) {
+	if strings.TrimSpace(js) == "" {
+		return defaultFilter, nil
+	}
+
+	f := defaultFilter
+	err := json.Unmarshal([]byte(js), &f)
+	if err != nil {
+		return defaultFilter, err
+	}
+
+	return f, nil
+}
+
+// DefaultFilter returns default filter
+func DefaultFilter() TFilter {
+	return defaultFilter
+}
+
+// key returns filter cache key, default filter has empty key
+func (f TFilter) key() string {
+	js, err := json.Marshal(f)
+	if err != nil {
+		return ""
+	}
+
+	def, _ := json.Marshal(defaultFilter)
+	if bytes.Equal(js, def) {
+		return ""
+	}
+
+	return string(js)
+}
+
+// filterTorrents returns torrents that pass the filter
+func filterTorrents(torrents []TTorrent, f TFilter) []TTorrent {
+	results := make([]TTorrent, 0)
+	for _, t := range torrents {
+		if f.match(t) {
+			results = append(results, t)
+		}
+	}
+
+	return results
+}
+
+// filterMovies returns movies that pass IMDb rating and votes of the filter, movies without rating do not pass minimums
+func filterMovies(movs []TMovie, f TFilter) []TMovie {
+	if f.MinImdbRating <= 0 && f.MinImdbVotes <= 0 {
+		return movs
+	}
+
+	results := make([]TMovie, 0)
+	for _, m := range movs {
+		if m.ImdbRating >= f.MinImdbRating && m.ImdbVotes >= f.MinImdbVotes {
+			results = append(results, m)
+		}
+	}
+
+	return results
+}
+
+// match checks if torrent passes the filter
+func (f TFilter) match(t TTorrent) bool {
+	if t.Seeders < f.MinSeeders {
+		return false
+	}
+
+	if f.MinSize > 0 && t.Size < f.MinSize {
+		return false
+	}
+
+	if f.MaxSize > 0 && t.Size > f.MaxSize {
+		return false
+	}
+
+	info := parseRelease(t.Title)
+
+	// Releases without resolution are mostly SD
+	resolution := 480
+	if info.Resolution != "" {
+		resolution, _ = strconv.Atoi(strings.TrimSuffix(info.Resolution, "p"))
+	}
+
+	if f.MinResolution > 0 && resolution < f.MinResolution {
+		return false
+	}
+
+	if f.MaxResolution > 0 && resolution > f.MaxResolution {
+		return false
+	}
+
+	// Releases without recognized source or codec are kept
+	if len(f.Sources) > 0 && info.Source != "" && !inStringsFold(info.Source, f.Sources) {
+		return false
+	}
+
+	if len(f.Codecs) > 0 && info.Codec != "" && !inStringsFold(info.Codec, f.Codecs) {
+		return false
+	}
+
+	title := strings.ToLower(t.Title)
+
+	for _, w := range f.Required {
+		if w != "" && !strings.Contains(title, strings.ToLower(w)) {
+			return false
+		}
+	}
+
+	for _, w := range f.Excluded {
+		if w != "" && strings.Contains(title, strings.ToLower(w)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// inStringsFold checks if string is in slice, case insensitive
+func inStringsFold(a string, b []string) bool {
+	for _, s := range b {
+		if strings.EqualFold(a, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package bukanir
+
+import (
+	"testing"
+)
+
+var filterTorrentsList = []TTorrent{
+	{Title: "Barbie.2023.HDCAM.x264-SUNSCREEN", Size: 1 << 30, Seeders: 100},
+	{Title: "Barbie.2023.1080p.WEB-DL.DDP5.1.H.264-FLUX", Size: 4 << 30, Seeders: 50},
+	{Title: "Barbie.2023.2160p.WEB-DL.DDP5.1.DV.HDR.H.265-FLUX", Size: 15 << 30, Seeders: 30},
+	{Title: "Barbie.2023.720p.WEBRip.x264.AAC-YTS", Size: 1 << 30, Seeders: 0},
+	{Title: "Barbie.2023.ITA.1080p.BluRay.x265-DDN", Size: 3 << 30, Seeders: 5},
+	{Title: "Barbie.2023.DVDRip.XviD", Size: 700 << 20, Seeders: 2},
+}
+
+func TestFilterTorrents(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter TFilter
+		want   []string
+	}{
+		{"default", defaultFilter, []string{
+			"Barbie.2023.HDCAM.x264-SUNSCREEN",
+			"Barbie.2023.1080p.WEB-DL.DDP5.1.H.264-FLUX",
+			"Barbie.2023.ITA.1080p.BluRay.x265-DDN",
+			"Barbie.2023.DVDRip.XviD",
+		}},
+		{"no limits", TFilter{}, []string{
+			"Barbie.2023.HDCAM.x264-SUNSCREEN",
+			"Barbie.2023.1080p.WEB-DL.DDP5.1.H.264-FLUX",
+			"Barbie.2023.2160p.WEB-DL.DDP5.1.DV.HDR.H.265-FLUX",
+			"Barbie.2023.720p.WEBRip.x264.AAC-YTS",
+			"Barbie.2023.ITA.1080p.BluRay.x265-DDN",
+			"Barbie.2023.DVDRip.XviD",
+		}},
+		{"resolution", TFilter{MinResolution: 720, MaxResolution: 1080}, []string{
+			"Barbie.2023.1080p.WEB-DL.DDP5.1.H.264-FLUX",
+			"Barbie.2023.720p.WEBRip.x264.AAC-YTS",
+			"Barbie.2023.ITA.1080p.BluRay.x265-DDN",
+		}},
+		{"sources", TFilter{Sources: []string{"web-dl", "BluRay", "DVDRip"}}, []string{
+			"Barbie.2023.1080p.WEB-DL.DDP5.1.H.264-FLUX",
+			"Barbie.2023.2160p.WEB-DL.DDP5.1.DV.HDR.H.265-FLUX",
+			"Barbie.2023.ITA.1080p.BluRay.x265-DDN",
+			"Barbie.2023.DVDRip.XviD",
+		}},
+		{"size", TFilter{MinSize: 2 << 30, MaxSize: 10 << 30}, []string{
+			"Barbie.2023.1080p.WEB-DL.DDP5.1.H.264-FLUX",
+			"Barbie.2023.ITA.1080p.BluRay.x265-DDN",
+		}},
+		{"seeders", TFilter{MinSeeders: 30}, []string{
+			"Barbie.2023.HDCAM.x264-SUNSCREEN",
+			"Barbie.2023.1080p.WEB-DL.DDP5.1.H.264-FLUX",
+			"Barbie.2023.2160p.WEB-DL.DDP5.1.DV.HDR.H.265-FLUX",
+		}},
+		{"codecs", TFilter{Codecs: []string{"x265"}}, []string{
+			"Barbie.2023.2160p.WEB-DL.DDP5.1.DV.HDR.H.265-FLUX",
+			"Barbie.2023.ITA.1080p.BluRay.x265-DDN",
+		}},
+		{"words", TFilter{Required: []string{"flux"}, Excluded: []string{"HDR"}}, []string{
+			"Barbie.2023.1080p.WEB-DL.DDP5.1.H.264-FLUX",
+		}},
+	}
+
+	for _, test := range tests {
+		results := filterTorrents(filterTorrentsList, test.filter)
+
+		var got []string
+		for _, r := range results {
+			got = append(got, r.Title)
+		}
+
+		if len(got) != len(test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+			continue
+		}
+
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+				break
+			}
+		}
+	}
+}
+
+func TestGetFilter(t *testing.T) {
+	f, err := getFilter("")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if f.MaxSize != defaultFilter.MaxSize || f.MinSeeders != defaultFilter.MinSeeders {
+		t.Error("empty filter is not default")
+	}
+
+	f, err = getFilter(`{"minResolution": 1080, "sources": ["WEB-DL"], "excluded": ["CAM"]}`)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if f.MinResolution != 1080 || len(f.Sources) != 1 || len(f.Excluded) != 1 || f.MaxSize != defaultFilter.MaxSize || f.MinSeeders != defaultFilter.MinSeeders {
+		t.Errorf("unexpected filter %+v", f)
+	}
+
+	f, err = getFilter(`{"maxSize": 0, "minSeeders": 0}`)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if f.MaxSize != 0 || f.MinSeeders != 0 {
+		t.Errorf("unexpected filter %+v, explicit zero values should be kept", f)
+	}
+
+	_, err = getFilter("{")
+	if err == nil {
+		t.Error("invalid filter accepted")
+	}
+}
+
+func TestFilterKey(t *testing.T) {
+	if DefaultFilter().key() != "" {
+		t.Error("default filter should have empty key")
+	}
+
+	f := DefaultFilter()
+	f.MinSeeders = 10
+	if f.key() == "" {
+		t.Error("custom filter should have key")
+	}
+}
@@ -9,28 +9,31 @@ import (
 )
 
 // tpbTop TPB top
-func tpbTop(category int, host string) {
+func (c *call) tpbTop(category int, host string) {
 	defer func() {
-		wgt.Done()
+		c.wgt.Done()
 		if r := recover(); r != nil {
 			log.Print("TPB: Recovered in tpbTop")
 		}
 	}()
 
-	pb := NewTpb(host)
+	pb := NewTpb(c.ctx, host)
 	results, err := pb.Top(category)
 	if err != nil {
 		log.Printf("ERROR: TPB Top: %v\n", err.Error())
+		c.fail(providerError("tpb", err))
 		return
 	}
 
-	torrents = append(torrents, results...)
+	c.Lock()
+	c.torrents = append(c.torrents, results...)
+	c.Unlock()
 }
 
 // tpbSearch TPB search
-func tpbSearch(query string, page int, host string, media string) {
+func (c *call) tpbSearch(query string, page int, host string, media string) {
 	defer func() {
-		wgt.Done()
+		c.wgt.Done()
 		if r := recover(); r != nil {
 			log.Print("TPB: Recovered in tpbSearch")
 		}
@@ -45,51 +48,60 @@ func tpbSearch(query string, page int, host string, media string) {
 		cats = "205,208"
 	}
 
-	pb := NewTpb(host)
+	pb := NewTpb(c.ctx, host)
 	results, err := pb.Search(query, page, cats)
 	if err != nil {
 		log.Printf("ERROR: TPB Search: %s\n", err.Error())
+		c.fail(providerError("tpb", err))
 		return
 	}
 
-	torrents = append(torrents, results...)
+	c.Lock()
+	c.torrents = append(c.torrents, results...)
+	c.Unlock()
 }
 
 // eztvSearch EZTV search
-func eztvSearch(query string, host string) {
+func (c *call) eztvSearch(query string, host string) {
 	defer func() {
-		wgt.Done()
+		c.wgt.Done()
 		if r := recover(); r != nil {
 			log.Print("TPB: Recovered in eztvSearch")
 		}
 	}()
 
-	ez := NewEztv(host)
+	ez := NewEztv(c.ctx, host)
 	results, err := ez.Search(query)
 	if err != nil {
 		log.Printf("ERROR: EZTV Search: %s\n", err.Error())
+		c.fail(providerError("eztv", err))
 		return
 	}
 
-	torrents = append(torrents, results...)
+	c.Lock()
+	c.torrents = append(c.torrents, results...)
+	c.Unlock()
 }
 
-// tmdbSearchMovie TMDB search movie
-func tmdbSearchMovie(t TTorrent, config *tmdbConfig) {
+// tmdbSearchMovie TMDB search movie, torrents are releases of the same movie
+func (c *call) tmdbSearchMovie(ts []TTorrent, config *tmdbConfig) {
 	defer func() {
-		wg.Done()
-		<-throttle
+		c.advance(len(ts))
+		c.wg.Done()
+		<-c.throttle
 
 		if r := recover(); r != nil {
 			log.Print("TMDB: Recovered in tmdbSearchMovie")
 		}
 	}()
 
-	md := NewTmdb(tmdbApiKey)
+	t := ts[0]
+	md := NewTmdb(c.ctx)
 
 	results, err := md.SearchMovie(t.FormattedTitle)
 	if err != nil {
 		log.Printf("ERROR: TMDB Search: %s\n", err.Error())
+		c.fail(metadataError(err))
 		return
 	}
 
@@ -97,64 +109,44 @@ func tmdbSearchMovie(t TTorrent, config *tmdbConfig) {
 		return
 	}
 
-	res := new(tmdbResult)
-	for _, result := range results.Results {
-		if result.Release_date != "" && t.Year != "" {
-			tmdbYear, _ := strconv.Atoi(getYear(result.Release_date))
-			torrentYear, _ := strconv.Atoi(t.Year)
-			if tmdbYear == torrentYear || tmdbYear == torrentYear-1 || tmdbYear == torrentYear+1 {
-				res = &result
-				break
-			}
+	res, confidence := md.match(t, results.Results, false)
+	if confidence < minConfidence {
+		if verbose {
+			log.Printf("TMDB: %s dropped, best match %d with confidence %.2f\n", t.FormattedTitle, res.Id, confidence)
 		}
+		return
+	}
+
+	if !c.allowed(res.Id, false) {
+		return
 	}
 
 	if res.Id == 0 || len(config.Images.Poster_sizes) < 5 {
 		return
 	}
 
-	posterSmall := config.Images.Base_url + config.Images.Poster_sizes[0] + res.Poster_path
-	posterMedium := config.Images.Base_url + config.Images.Poster_sizes[1] + res.Poster_path
-	posterLarge := config.Images.Base_url + config.Images.Poster_sizes[3] + res.Poster_path
-	posterXLarge := config.Images.Base_url + config.Images.Poster_sizes[4] + res.Poster_path
-
-	m := TMovie{
-		res.Id,
-		res.Title,
-		getYear(res.Release_date),
-		posterSmall,
-		posterMedium,
-		posterLarge,
-		posterXLarge,
-		int64(t.Size),
-		t.SizeHuman,
-		t.Seeders,
-		t.MagnetLink,
-		t.Title,
-		t.Category,
-		t.Season,
-		t.Episode,
-		getQuality(t.Title),
-	}
-	movies = append(movies, m)
+	c.addMovies(ts, res.Id, res.Title, getYear(res.Release_date), res.Poster_path, res.Backdrop_path, confidence, config)
 }
 
-// tmdbSearchTv TMDB search tv show
-func tmdbSearchTv(t TTorrent, config *tmdbConfig) {
+// tmdbSearchTv TMDB search tv show, torrents are releases of the same season
+func (c *call) tmdbSearchTv(ts []TTorrent, config *tmdbConfig) {
 	defer func() {
-		wg.Done()
-		<-throttle
+		c.advance(len(ts))
+		c.wg.Done()
+		<-c.throttle
 
 		if r := recover(); r != nil {
 			log.Print("TMDB: Recovered in tmdbSearch")
 		}
 	}()
 
-	md := NewTmdb(tmdbApiKey)
+	t := ts[0]
+	md := NewTmdb(c.ctx)
 
 	results, err := md.SearchTv(t.FormattedTitle)
 	if err != nil {
 		log.Printf("ERROR: TMDB Search: %s\n", err.Error())
+		c.fail(metadataError(err))
 		return
 	}
 
@@ -162,7 +154,18 @@ func tmdbSearchTv(t TTorrent, config *tmdbConfig) {
 		return
 	}
 
-	res := &results.Results[0]
+	res, confidence := md.match(t, results.Results, true)
+	if confidence < minConfidence {
+		if verbose {
+			log.Printf("TMDB: %s dropped, best match %d with confidence %.2f\n", t.FormattedTitle, res.Id, confidence)
+		}
+		return
+	}
+
+	if !c.allowed(res.Id, true) {
+		return
+	}
+
 	if res.Id == 0 {
 		return
 	}
@@ -170,6 +173,7 @@ func tmdbSearchTv(t TTorrent, config *tmdbConfig) {
 	p, err := md.GetTvImages(strconv.Itoa(res.Id), t.Season)
 	if err != nil {
 		log.Printf("ERROR: GetTvImages: %s\n", err.Error())
+		c.fail(metadataError(err))
 		return
 	}
 
@@ -177,61 +181,99 @@ func tmdbSearchTv(t TTorrent, config *tmdbConfig) {
 		return
 	}
 
-	posterSmall := config.Images.Base_url + config.Images.Poster_sizes[0] + p.Posters[0].File_path
-	posterMedium := config.Images.Base_url + config.Images.Poster_sizes[1] + p.Posters[0].File_path
-	posterLarge := config.Images.Base_url + config.Images.Poster_sizes[3] + p.Posters[0].File_path
-	posterXLarge := config.Images.Base_url + config.Images.Poster_sizes[4] + p.Posters[0].File_path
-
-	m := TMovie{
-		res.Id,
-		res.Name,
-		getYear(res.First_air_date),
-		posterSmall,
-		posterMedium,
-		posterLarge,
-		posterXLarge,
-		int64(t.Size),
-		t.SizeHuman,
-		t.Seeders,
-		t.MagnetLink,
-		t.Title,
-		t.Category,
-		t.Season,
-		t.Episode,
-		getQuality(t.Title),
-	}
-	movies = append(movies, m)
+	c.addMovies(ts, res.Id, res.Name, getYear(res.First_air_date), p.Posters[0].File_path, res.Backdrop_path, confidence, config)
+}
+
+// addMovies adds movie for each torrent resolved to the same TMDB result with match confidence
+func (c *call) addMovies(ts []TTorrent, id int, title, year, posterPath, backdropPath string, confidence float64, config *tmdbConfig) {
+	if len(ts) == 0 {
+		return
+	}
+
+	posterSmall := config.Images.Base_url + config.Images.Poster_sizes[0] + posterPath
+	posterMedium := config.Images.Base_url + config.Images.Poster_sizes[1] + posterPath
+	posterLarge := config.Images.Base_url + config.Images.Poster_sizes[3] + posterPath
+	posterXLarge := config.Images.Base_url + config.Images.Poster_sizes[4] + posterPath
+	backdropSmall := imageUrl(config, config.Images.Backdrop_sizes, 0, backdropPath)
+	backdropMedium := imageUrl(config, config.Images.Backdrop_sizes, 1, backdropPath)
+	backdropLarge := imageUrl(config, config.Images.Backdrop_sizes, 2, backdropPath)
+
+	imdbId, imdbRating, imdbVotes := c.imdbRating(id, ts[0].Category == CategoryTV || ts[0].Category == CategoryHDTV, title, year)
+
+	for _, t := range ts {
+		m := TMovie{
+			id,
+			title,
+			year,
+			posterSmall,
+			posterMedium,
+			posterLarge,
+			posterXLarge,
+			backdropSmall,
+			backdropMedium,
+			backdropLarge,
+			int64(t.Size),
+			t.SizeHuman,
+			t.Seeders,
+			t.MagnetLink,
+			t.Title,
+			t.Category,
+			t.Season,
+			t.Episode,
+			getQuality(t.Title),
+			parseRelease(t.Title),
+			t.Provider,
+			t.Leechers,
+			t.Added,
+			0,
+			TScore{},
+			confidence,
+			confidence >= verifiedConfidence,
+			imdbId,
+			imdbRating,
+			imdbVotes,
+		}
+		c.addMovie(m)
+	}
 }
 
 // tmdbSummary TMDB summary
-func tmdbSummary(id int, category int, season int, episode int) {
+func (c *call) tmdbSummary(id int, category int, season int, episode int) {
 	defer func() {
-		wg.Done()
+		c.wg.Done()
 		if r := recover(); r != nil {
 			log.Print("TMDB: Recovered in tmdbSummary")
 		}
 	}()
 
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(c.ctx)
+	config, err := md.GetConfig()
+	if err != nil {
+		log.Printf("ERROR: TMDB GetConfig: %s\n", err.Error())
+		c.fail(metadataError(err))
+		return
+	}
 
-	var err error
 	var res, res_season tmdbMovie
 
 	if category == CategoryTV || category == CategoryHDTV {
 		res, err = md.GetTvDetails(strconv.Itoa(id), -1)
 		if err != nil {
 			log.Printf("ERROR: GetTvDetails: %v\n", err.Error())
+			c.fail(metadataError(err))
 			return
 		}
 		res_season, err = md.GetTvDetails(strconv.Itoa(id), season)
 		if err != nil {
 			log.Printf("ERROR: GetTvDetails season: %v\n", err.Error())
+			c.fail(metadataError(err))
 			return
 		}
 	} else {
 		res, err = md.GetMovieDetails(strconv.Itoa(id))
 		if err != nil {
 			log.Printf("ERROR: GetMovieDetails: %v\n", err.Error())
+			c.fail(metadataError(err))
 			return
 		}
 	}
@@ -240,20 +282,29 @@ func tmdbSummary(id int, category int, season int, episode int) {
 	var overview string = res.Overview
 	var director string = getDirector(res.Credits.Crew)
 	var directorId int = getDirectorId(res.Credits.Crew)
-	var video = getVideo(res.Videos.Results)
+	var video = getVideo(res.Videos.Results, md.Language)
 	var casts []tmdbCast = res.Credits.Cast
 	var genres []tmdbGenre = res.Genres
 	var imdbId string = strings.Replace(res.Imdb_id, "tt", "", -1)
+	var releaseDate string = res.Release_date
+	var episodeTitle, episodeStill string
 
 	if category == CategoryTV || category == CategoryHDTV {
-		if len(res_season.Episodes) > episode-1 {
-			o := res_season.Episodes[episode-1].Overview
-			if o != "" {
-				overview = o
+		releaseDate = res.First_air_date
+
+		if e, ok := seasonEpisode(res_season, episode); ok {
+			if e.Overview != "" {
+				overview = e.Overview
+			}
+
+			episodeTitle = e.Name
+			episodeStill = imageUrl(config, config.Images.Still_sizes, 2, e.Still_path)
+			if e.Air_date != "" {
+				releaseDate = e.Air_date
 			}
 
-			if len(res_season.Episodes[episode-1].Crew) > 0 {
-				d := getDirector(res_season.Episodes[episode-1].Crew)
+			if len(e.Crew) > 0 {
+				d := getDirector(e.Crew)
 				if d != "" {
 					director = d
 				}
@@ -261,7 +312,7 @@ func tmdbSummary(id int, category int, season int, episode int) {
 		}
 
 		if len(res_season.Videos.Results) > 0 {
-			v := getVideo(res_season.Videos.Results)
+			v := getVideo(res_season.Videos.Results, md.Language)
 			if v != "" {
 				video = v
 			}
@@ -279,13 +330,21 @@ func tmdbSummary(id int, category int, season int, episode int) {
 			ext, err := md.GetTvExternals(strconv.Itoa(id))
 			if err != nil {
 				log.Printf("ERROR: GetTvExternals: %v\n", err.Error())
+				c.fail(metadataError(err))
 				return
 			}
 			imdbId = strings.Replace(ext.Imdb_id, "tt", "", -1)
 		}
 	}
 
-	details = TSummary{
+	imdbRating, imdbVotes := imdbById(imdbId)
+
+	var collection tmdbCollection
+	if res.Belongs_to_collection != nil {
+		collection = *res.Belongs_to_collection
+	}
+
+	c.details = TSummary{
 		id,
 		getCast(casts),
 		getCastIds(casts),
@@ -298,28 +357,43 @@ func tmdbSummary(id int, category int, season int, episode int) {
 		overview,
 		res.Runtime,
 		imdbId,
+		imdbRating,
+		imdbVotes,
+		collection.Id,
+		collection.Name,
+		imageUrl(config, config.Images.Backdrop_sizes, 2, res.Backdrop_path),
+		releaseDate,
+		res.Original_language,
+		getLanguages(res.Spoken_languages),
+		getCountries(res.Production_countries, res.Origin_country),
+		res.Status,
+		episodeTitle,
+		episodeStill,
+		md.stale,
 	}
 }
 
 // tmdbAutoComplete TMDB autocomplete
-func tmdbAutoComplete(query string) {
+func (c *call) tmdbAutoComplete(query string) {
 	defer func() {
-		wg.Done()
+		c.wg.Done()
 		if r := recover(); r != nil {
 			log.Print("TMDB: Recovered in tmdbAutocomplete")
 		}
 	}()
 
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(c.ctx)
 
 	movies, err := md.AutoCompleteMovie(query)
 	if err != nil {
 		log.Printf("ERROR: AutoCompleteMovie: %v\n", err.Error())
+		c.fail(metadataError(err))
 		return
 	}
 	tvs, err := md.AutoCompleteTv(query)
 	if err != nil {
 		log.Printf("ERROR: AutoCompleteTv: %v\n", err.Error())
+		c.fail(metadataError(err))
 		return
 	}
 
@@ -335,8 +409,9 @@ func tmdbAutoComplete(query string) {
 		a := TItem{
 			movie.Title,
 			year,
+			false,
 		}
-		autocompletes = append(autocompletes, a)
+		c.items = append(c.items, a)
 	}
 
 	for _, tv := range tvs.Results {
@@ -347,31 +422,34 @@ func tmdbAutoComplete(query string) {
 		a := TItem{
 			tv.Original_name,
 			year,
+			false,
 		}
-		autocompletes = append(autocompletes, a)
+		c.items = append(c.items, a)
 	}
 }
 
 // tmdbPopular TMDB popular
-func tmdbPopular() {
+func (c *call) tmdbPopular() {
 	defer func() {
-		wg.Done()
+		c.wg.Done()
 		if r := recover(); r != nil {
 			log.Print("TMDB: Recovered in tmdbPopular")
 		}
 	}()
 
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(c.ctx)
 
 	movies, err := md.PopularMovies()
 	if err != nil {
 		log.Printf("ERROR: PopularMovies: %v\n", err.Error())
+		c.fail(metadataError(err))
 		return
 	}
 
 	tvs, err := md.PopularTv()
 	if err != nil {
 		log.Printf("ERROR: PopularTv: %v\n", err.Error())
+		c.fail(metadataError(err))
 		return
 	}
 
@@ -383,40 +461,44 @@ func tmdbPopular() {
 		p := TItem{}
 		p.Title = movie.Title
 		p.Year = getYear(movie.Release_date)
-		popular = append(popular, p)
+		p.Stale = md.stale
+		c.items = append(c.items, p)
 	}
 
 	sep := TItem{}
-	popular = append(popular, sep)
+	c.items = append(c.items, sep)
 
 	for _, tv := range tvs.Results {
 		t := TItem{}
 		t.Title = tv.Name
 		t.Year = getYear(tv.First_air_date)
-		popular = append(popular, t)
+		t.Stale = md.stale
+		c.items = append(c.items, t)
 	}
 }
 
 // tmdbTopRated TMDB top rated
-func tmdbTopRated() {
+func (c *call) tmdbTopRated() {
 	defer func() {
-		wg.Done()
+		c.wg.Done()
 		if r := recover(); r != nil {
 			log.Print("TMDB: Recovered in tmdbTopRated")
 		}
 	}()
 
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(c.ctx)
 
 	movies, err := md.TopRatedMovies()
 	if err != nil {
 		log.Printf("ERROR: TopRatedMovies: %v\n", err.Error())
+		c.fail(metadataError(err))
 		return
 	}
 
 	tvs, err := md.TopRatedTv()
 	if err != nil {
 		log.Printf("ERROR: TopRatedTv: %v\n", err.Error())
+		c.fail(metadataError(err))
 		return
 	}
 
@@ -428,34 +510,37 @@ func tmdbTopRated() {
 		p := TItem{}
 		p.Title = movie.Title
 		p.Year = getYear(movie.Release_date)
-		toprated = append(toprated, p)
+		p.Stale = md.stale
+		c.items = append(c.items, p)
 	}
 
 	sep := TItem{}
-	toprated = append(toprated, sep)
+	c.items = append(c.items, sep)
 
 	for _, tv := range tvs.Results {
 		t := TItem{}
 		t.Title = tv.Name
 		t.Year = getYear(tv.First_air_date)
-		toprated = append(toprated, t)
+		t.Stale = md.stale
+		c.items = append(c.items, t)
 	}
 }
 
 // tmdbGetGenres TMDB genres
-func tmdbGetGenres() {
+func (c *call) tmdbGetGenres() {
 	defer func() {
-		wg.Done()
+		c.wg.Done()
 		if r := recover(); r != nil {
 			log.Print("TMDB: Recovered in tmdbGenres")
 		}
 	}()
 
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(c.ctx)
 
 	gn, err := md.Genres()
 	if err != nil {
 		log.Printf("ERROR: Genres: %v\n", err.Error())
+		c.fail(metadataError(err))
 		return
 	}
 
@@ -464,24 +549,25 @@ func tmdbGetGenres() {
 	}
 
 	for _, g := range gn.Genres {
-		p := TGenre{g.Id, g.Name}
-		genres = append(genres, p)
+		p := TGenre{g.Id, g.Name, md.stale}
+		c.genres = append(c.genres, p)
 	}
 }
 
 // tmdbByGenre TMDB movies by genre
-func tmdbByGenre(id int, limit int, tpbHost string) {
+func (c *call) tmdbByGenre(id int, limit int, tpbHost string, f TFilter) {
 	defer func() {
-		wg.Done()
+		c.wg.Done()
 		if r := recover(); r != nil {
 			log.Print("TMDB: Recovered in tmdbByGenre")
 		}
 	}()
 
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(c.ctx)
 	config, err := md.GetConfig()
 	if err != nil {
 		log.Printf("ERROR: TMDB GetConfig: %s\n", err.Error())
+		c.fail(metadataError(err))
 		return
 	}
 
@@ -490,6 +576,7 @@ func tmdbByGenre(id int, limit int, tpbHost string) {
 		mp, err := md.Genre(id, n)
 		if err != nil {
 			log.Printf("ERROR: Genre: %v\n", err.Error())
+			c.fail(metadataError(err))
 			return
 		}
 		m = append(m, mp.Results...)
@@ -499,7 +586,7 @@ func tmdbByGenre(id int, limit int, tpbHost string) {
 		return
 	}
 
-	pb := NewTpb(tpbHost)
+	pb := NewTpb(c.ctx, tpbHost)
 
 	if limit > 0 {
 		if limit > len(m) {
@@ -511,7 +598,7 @@ func tmdbByGenre(id int, limit int, tpbHost string) {
 	var th = make(chan int, 3*runtime.NumCPU())
 
 	searchTorrents := func(r tmdbResult) {
-		defer wgt.Done()
+		defer c.wgt.Done()
 		defer func() {
 			if r := recover(); r != nil {
 				log.Print("TMDB: Recovered in searchTorrents")
@@ -526,11 +613,15 @@ func tmdbByGenre(id int, limit int, tpbHost string) {
 			return
 		}
 
-		results, err := pb.Search(r.Title, 0, "201,207")
+		query := md.englishTitle(r, false)
+
+		results, err := pb.Search(query, 0, "201,207")
 		if err != nil {
 			return
 		}
 
+		results = filterTorrents(results, f)
+
 		if len(results) == 0 {
 			return
 		}
@@ -543,7 +634,7 @@ func tmdbByGenre(id int, limit int, tpbHost string) {
 				tmdbYear, _ := strconv.Atoi(getYear(r.Release_date))
 				torrentYear, _ := strconv.Atoi(rt.Year)
 				if tmdbYear == torrentYear || tmdbYear == torrentYear-1 || tmdbYear == torrentYear+1 {
-					if getTitle(r.Title) == rt.FormattedTitle || getTitle(r.Original_title) == rt.FormattedTitle {
+					if getTitle(query) == rt.FormattedTitle || getTitle(r.Title) == rt.FormattedTitle || getTitle(r.Original_title) == rt.FormattedTitle {
 						t = rt
 						break
 					}
@@ -563,6 +654,9 @@ func tmdbByGenre(id int, limit int, tpbHost string) {
 			config.Images.Base_url + config.Images.Poster_sizes[1] + r.Poster_path,
 			config.Images.Base_url + config.Images.Poster_sizes[3] + r.Poster_path,
 			config.Images.Base_url + config.Images.Poster_sizes[4] + r.Poster_path,
+			imageUrl(config, config.Images.Backdrop_sizes, 0, r.Backdrop_path),
+			imageUrl(config, config.Images.Backdrop_sizes, 1, r.Backdrop_path),
+			imageUrl(config, config.Images.Backdrop_sizes, 2, r.Backdrop_path),
 			int64(t.Size),
 			t.SizeHuman,
 			t.Seeders,
@@ -572,31 +666,49 @@ func tmdbByGenre(id int, limit int, tpbHost string) {
 			t.Season,
 			t.Episode,
 			getQuality(t.Title),
-		}
-		bygenre = append(bygenre, movie)
+			parseRelease(t.Title),
+			t.Provider,
+			t.Leechers,
+			t.Added,
+			0,
+			TScore{},
+			1,
+			true,
+			"",
+			0,
+			0,
+		}
+		c.Lock()
+		c.movies = append(c.movies, movie)
+		c.Unlock()
 	}
 
 	for _, res := range m {
+		if c.ctx.Err() != nil {
+			break
+		}
+
 		th <- 1
-		wgt.Add(1)
+		c.wgt.Add(1)
 		go searchTorrents(res)
 	}
-	wgt.Wait()
+	c.wgt.Wait()
 }
 
 // tmdbWithCast TMDB movies by cast
-func tmdbWithCast(id int, limit int, tpbHost string) {
+func (c *call) tmdbWithCast(id int, limit int, tpbHost string) {
 	defer func() {
-		wg.Done()
+		c.wg.Done()
 		if r := recover(); r != nil {
 			log.Print("TMDB: Recovered in tmdbByGenre")
 		}
 	}()
 
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(c.ctx)
 	config, err := md.GetConfig()
 	if err != nil {
 		log.Printf("ERROR: TMDB GetConfig: %s\n", err.Error())
+		c.fail(metadataError(err))
 		return
 	}
 
@@ -605,6 +717,7 @@ func tmdbWithCast(id int, limit int, tpbHost string) {
 		mp, err := md.MoviesWithCast(id, n)
 		if err != nil {
 			log.Printf("ERROR: MoviesWithCast: %v\n", err.Error())
+			c.fail(metadataError(err))
 			return
 		}
 		m = append(m, mp.Results...)
@@ -614,7 +727,7 @@ func tmdbWithCast(id int, limit int, tpbHost string) {
 		return
 	}
 
-	pb := NewTpb(tpbHost)
+	pb := NewTpb(c.ctx, tpbHost)
 
 	if limit > 0 {
 		if limit > len(m) {
@@ -626,7 +739,7 @@ func tmdbWithCast(id int, limit int, tpbHost string) {
 	var th = make(chan int, 3*runtime.NumCPU())
 
 	searchTorrents := func(r tmdbResult) {
-		defer wgt.Done()
+		defer c.wgt.Done()
 		defer func() {
 			if r := recover(); r != nil {
 				log.Print("TMDB: Recovered in searchTorrents")
@@ -641,11 +754,15 @@ func tmdbWithCast(id int, limit int, tpbHost string) {
 			return
 		}
 
-		results, err := pb.Search(r.Title, 0, "201,207")
+		query := md.englishTitle(r, false)
+
+		results, err := pb.Search(query, 0, "201,207")
 		if err != nil {
 			return
 		}
 
+		results = filterTorrents(results, defaultFilter)
+
 		if len(results) == 0 {
 			return
 		}
@@ -658,7 +775,7 @@ func tmdbWithCast(id int, limit int, tpbHost string) {
 				tmdbYear, _ := strconv.Atoi(getYear(r.Release_date))
 				torrentYear, _ := strconv.Atoi(rt.Year)
 				if tmdbYear == torrentYear || tmdbYear == torrentYear-1 || tmdbYear == torrentYear+1 {
-					if getTitle(r.Title) == rt.FormattedTitle || getTitle(r.Original_title) == rt.FormattedTitle {
+					if getTitle(query) == rt.FormattedTitle || getTitle(r.Title) == rt.FormattedTitle || getTitle(r.Original_title) == rt.FormattedTitle {
 						t = rt
 						break
 					}
@@ -678,6 +795,9 @@ func tmdbWithCast(id int, limit int, tpbHost string) {
 			config.Images.Base_url + config.Images.Poster_sizes[1] + r.Poster_path,
 			config.Images.Base_url + config.Images.Poster_sizes[3] + r.Poster_path,
 			config.Images.Base_url + config.Images.Poster_sizes[4] + r.Poster_path,
+			imageUrl(config, config.Images.Backdrop_sizes, 0, r.Backdrop_path),
+			imageUrl(config, config.Images.Backdrop_sizes, 1, r.Backdrop_path),
+			imageUrl(config, config.Images.Backdrop_sizes, 2, r.Backdrop_path),
 			int64(t.Size),
 			t.SizeHuman,
 			t.Seeders,
@@ -687,31 +807,49 @@ func tmdbWithCast(id int, limit int, tpbHost string) {
 			t.Season,
 			t.Episode,
 			getQuality(t.Title),
-		}
-		bycast = append(bycast, movie)
+			parseRelease(t.Title),
+			t.Provider,
+			t.Leechers,
+			t.Added,
+			0,
+			TScore{},
+			1,
+			true,
+			"",
+			0,
+			0,
+		}
+		c.Lock()
+		c.movies = append(c.movies, movie)
+		c.Unlock()
 	}
 
 	for _, res := range m {
+		if c.ctx.Err() != nil {
+			break
+		}
+
 		th <- 1
-		wgt.Add(1)
+		c.wgt.Add(1)
 		go searchTorrents(res)
 	}
-	wgt.Wait()
+	c.wgt.Wait()
 }
 
 // tmdbWithCrew TMDB movies by crew
-func tmdbWithCrew(id int, limit int, tpbHost string) {
+func (c *call) tmdbWithCrew(id int, limit int, tpbHost string) {
 	defer func() {
-		wg.Done()
+		c.wg.Done()
 		if r := recover(); r != nil {
 			log.Print("TMDB: Recovered in tmdbByGenre")
 		}
 	}()
 
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(c.ctx)
 	config, err := md.GetConfig()
 	if err != nil {
 		log.Printf("ERROR: TMDB GetConfig: %s\n", err.Error())
+		c.fail(metadataError(err))
 		return
 	}
 
@@ -720,6 +858,7 @@ func tmdbWithCrew(id int, limit int, tpbHost string) {
 		mp, err := md.MoviesWithCrew(id, n)
 		if err != nil {
 			log.Printf("ERROR: MoviesWithCast: %v\n", err.Error())
+			c.fail(metadataError(err))
 			return
 		}
 		m = append(m, mp.Results...)
@@ -729,7 +868,7 @@ func tmdbWithCrew(id int, limit int, tpbHost string) {
 		return
 	}
 
-	pb := NewTpb(tpbHost)
+	pb := NewTpb(c.ctx, tpbHost)
 
 	if limit > 0 {
 		if limit > len(m) {
@@ -741,7 +880,7 @@ func tmdbWithCrew(id int, limit int, tpbHost string) {
 	var th = make(chan int, 3*runtime.NumCPU())
 
 	searchTorrents := func(r tmdbResult) {
-		defer wgt.Done()
+		defer c.wgt.Done()
 		defer func() {
 			if r := recover(); r != nil {
 				log.Print("TMDB: Recovered in searchTorrents")
@@ -756,11 +895,15 @@ func tmdbWithCrew(id int, limit int, tpbHost string) {
 			return
 		}
 
-		results, err := pb.Search(r.Title, 0, "201,207")
+		query := md.englishTitle(r, false)
+
+		results, err := pb.Search(query, 0, "201,207")
 		if err != nil {
 			return
 		}
 
+		results = filterTorrents(results, defaultFilter)
+
 		if len(results) == 0 {
 			return
 		}
@@ -773,7 +916,7 @@ func tmdbWithCrew(id int, limit int, tpbHost string) {
 				tmdbYear, _ := strconv.Atoi(getYear(r.Release_date))
 				torrentYear, _ := strconv.Atoi(rt.Year)
 				if tmdbYear == torrentYear || tmdbYear == torrentYear-1 || tmdbYear == torrentYear+1 {
-					if getTitle(r.Title) == rt.FormattedTitle || getTitle(r.Original_title) == rt.FormattedTitle {
+					if getTitle(query) == rt.FormattedTitle || getTitle(r.Title) == rt.FormattedTitle || getTitle(r.Original_title) == rt.FormattedTitle {
 						t = rt
 						break
 					}
@@ -793,6 +936,9 @@ func tmdbWithCrew(id int, limit int, tpbHost string) {
 			config.Images.Base_url + config.Images.Poster_sizes[1] + r.Poster_path,
 			config.Images.Base_url + config.Images.Poster_sizes[3] + r.Poster_path,
 			config.Images.Base_url + config.Images.Poster_sizes[4] + r.Poster_path,
+			imageUrl(config, config.Images.Backdrop_sizes, 0, r.Backdrop_path),
+			imageUrl(config, config.Images.Backdrop_sizes, 1, r.Backdrop_path),
+			imageUrl(config, config.Images.Backdrop_sizes, 2, r.Backdrop_path),
 			int64(t.Size),
 			t.SizeHuman,
 			t.Seeders,
@@ -802,14 +948,31 @@ func tmdbWithCrew(id int, limit int, tpbHost string) {
 			t.Season,
 			t.Episode,
 			getQuality(t.Title),
-		}
-		bycrew = append(bycrew, movie)
+			parseRelease(t.Title),
+			t.Provider,
+			t.Leechers,
+			t.Added,
+			0,
+			TScore{},
+			1,
+			true,
+			"",
+			0,
+			0,
+		}
+		c.Lock()
+		c.movies = append(c.movies, movie)
+		c.Unlock()
 	}
 
 	for _, res := range m {
+		if c.ctx.Err() != nil {
+			break
+		}
+
 		th <- 1
-		wgt.Add(1)
+		c.wgt.Add(1)
 		go searchTorrents(res)
 	}
-	wgt.Wait()
+	c.wgt.Wait()
 }
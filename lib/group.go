@@ -0,0 +1,195 @@
+package bukanir
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Default profile, used when no profile is given
+var defaultProfile = TProfile{
+	Resolution: 1080,
+	Sources:    []string{"BluRay", "WEB-DL", "WEBRip", "HDTV", "HDRip", "DVDRip"},
+	Codecs:     []string{"x264", "x265"},
+	MaxSize:    5120 * 1024 * 1024,
+}
+
+// DefaultProfile returns default preference profile
+func DefaultProfile() TProfile {
+	return defaultProfile
+}
+
+// getProfile returns profile from json string, empty string returns default profile and missing fields are default
+func getProfile(js string) (TProfile, error) {
+	if strings.TrimSpace(js) == "" {
+		return defaultProfile, nil
+	}
+
+	// Lists are copied, json arrays are decoded into their backing arrays
+	p := defaultProfile
+	p.Sources = append([]string{}, defaultProfile.Sources...)
+	p.Codecs = append([]string{}, defaultProfile.Codecs...)
+
+	err := json.Unmarshal([]byte(js), &p)
+	if err != nil {
+		return defaultProfile, err
+	}
+
+	return p, nil
+}
+
+// groupMovies groups releases by TMDB id, and by season/episode for tv shows.
+// Groups are kept in order of their first release.
+func groupMovies(movs []TMovie, p TProfile) []TGroup {
+	groups := make([]TGroup, 0)
+	index := make(map[string]int)
+
+	for _, m := range movs {
+		key := groupKey(m)
+
+		n, ok := index[key]
+		if !ok {
+			g := TGroup{
+				m.Id,
+				m.Title,
+				m.Year,
+				m.PosterSmall,
+				m.PosterMedium,
+				m.PosterLarge,
+				m.PosterXLarge,
+				m.Category,
+				m.Season,
+				m.Episode,
+				0,
+				make([]TRelease, 0),
+			}
+
+			groups = append(groups, g)
+			n = len(groups) - 1
+			index[key] = n
+		}
+
+		r := TRelease{
+			m.Release,
+			m.MagnetLink,
+			m.Size,
+			m.SizeHuman,
+			m.Seeders,
+			m.Quality,
+			m.Provider,
+			m.ReleaseInfo,
+		}
+		groups[n].Releases = append(groups[n].Releases, r)
+	}
+
+	for n := range groups {
+		sort.Stable(ByRSeeders(groups[n].Releases))
+		groups[n].Best = p.best(groups[n].Releases)
+	}
+
+	return groups
+}
+
+// groupKey returns grouping key for movie
+func groupKey(m TMovie) string {
+	if m.Category == CategoryTV || m.Category == CategoryHDTV {
+		return "tv" + strconv.Itoa(m.Id) + "s" + strconv.Itoa(m.Season) + "e" + strconv.Itoa(m.Episode)
+	}
+
+	return "movie" + strconv.Itoa(m.Id)
+}
+
+// best returns index of the release that best matches profile
+func (p TProfile) best(releases []TRelease) int {
+	best := 0
+	bestScore := 0
+
+	for n, r := range releases {
+		score := p.score(r)
+		if n == 0 || score > bestScore {
+			best = n
+			bestScore = score
+		}
+	}
+
+	return best
+}
+
+// score returns how well release matches profile, higher is better
+func (p TProfile) score(r TRelease) int {
+	var score int
+	info := r.ReleaseInfo
+
+	if p.Resolution > 0 {
+		// Releases without resolution are mostly SD
+		resolution := 480
+		if info.Resolution != "" {
+			resolution, _ = strconv.Atoi(strings.TrimSuffix(info.Resolution, "p"))
+		}
+
+		if resolution == p.Resolution {
+			score += 100
+		} else if resolution > p.Resolution {
+			score += 60
+		} else {
+			score += 50 * resolution / p.Resolution
+		}
+	}
+
+	score += preference(info.Source, p.Sources, 50)
+	score += preference(info.Codec, p.Codecs, 20)
+
+	if len(p.Languages) > 0 {
+		// Releases without language tag are mostly english
+		langs := info.Languages
+		if len(langs) == 0 {
+			langs = []string{"English"}
+		}
+
+		found := false
+		for _, l := range langs {
+			if l == "Multi" || l == "Dual" || inStringsFold(l, p.Languages) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			score -= 100
+		}
+	}
+
+	if p.HDR && (info.HDR != "" || info.DolbyVision) {
+		score += 10
+	}
+
+	if info.Proper || info.Repack {
+		score += 5
+	}
+
+	if p.MaxSize > 0 && r.Size > p.MaxSize {
+		score -= 100
+	}
+
+	if r.Seeders == 0 {
+		score -= 200
+	}
+
+	return score
+}
+
+// preference returns score for value by its position in preferred list
+func preference(value string, preferred []string, max int) int {
+	if value == "" || len(preferred) == 0 {
+		return 0
+	}
+
+	for n, p := range preferred {
+		if strings.EqualFold(value, p) {
+			return max - n*max/len(preferred)
+		}
+	}
+
+	return -max
+}
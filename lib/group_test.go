@@ -0,0 +1,92 @@
+package bukanir
+
+import (
+	"testing"
+)
+
+func groupMovie(id int, release string, size int64, seeders, category, season, episode int) TMovie {
+	return TMovie{
+		Id:          id,
+		Release:     release,
+		Size:        size,
+		Seeders:     seeders,
+		Category:    category,
+		Season:      season,
+		Episode:     episode,
+		ReleaseInfo: parseRelease(release),
+	}
+}
+
+var groupMoviesList = []TMovie{
+	groupMovie(346698, "Barbie.2023.HDCAM.x264-SUNSCREEN", 1<<30, 500, CategoryMovies, 0, 0),
+	groupMovie(346698, "Barbie.2023.1080p.WEB-DL.DDP5.1.H.264-FLUX", 4<<30, 50, CategoryHDmovies, 0, 0),
+	groupMovie(1396, "Breaking.Bad.S01E01.720p.BluRay.x264-DEMAND", 1<<30, 40, CategoryHDTV, 1, 1),
+	groupMovie(346698, "Barbie.2023.ITA.1080p.BluRay.x265-DDN", 3<<30, 80, CategoryHDmovies, 0, 0),
+	groupMovie(1396, "Breaking.Bad.S01E02.720p.BluRay.x264-DEMAND", 1<<30, 30, CategoryHDTV, 1, 2),
+	groupMovie(1396, "Breaking.Bad.S01E01.1080p.BluRay.x264-ROVERS", 2<<30, 20, CategoryHDTV, 1, 1),
+	groupMovie(1396, "Breaking Bad 2008 1080p BluRay x264", 4<<30, 10, CategoryHDmovies, 0, 0),
+}
+
+func TestGroupMovies(t *testing.T) {
+	groups := groupMovies(groupMoviesList, defaultProfile)
+
+	if len(groups) != 4 {
+		t.Fatalf("got %d groups, want 4", len(groups))
+	}
+
+	tests := []struct {
+		id       int
+		episode  int
+		releases int
+		best     string
+	}{
+		{346698, 0, 3, "Barbie.2023.1080p.WEB-DL.DDP5.1.H.264-FLUX"},
+		{1396, 1, 2, "Breaking.Bad.S01E01.1080p.BluRay.x264-ROVERS"},
+		{1396, 2, 1, "Breaking.Bad.S01E02.720p.BluRay.x264-DEMAND"},
+		{1396, 0, 1, "Breaking Bad 2008 1080p BluRay x264"},
+	}
+
+	for n, test := range tests {
+		g := groups[n]
+
+		if g.Id != test.id || g.Episode != test.episode || len(g.Releases) != test.releases {
+			t.Errorf("group %d: got id %d episode %d with %d releases", n, g.Id, g.Episode, len(g.Releases))
+			continue
+		}
+
+		if g.Releases[g.Best].Release != test.best {
+			t.Errorf("group %d: got best %s, want %s", n, g.Releases[g.Best].Release, test.best)
+		}
+	}
+
+	if groups[0].Releases[0].Seeders < groups[0].Releases[1].Seeders {
+		t.Error("releases are not sorted by seeders")
+	}
+
+	p := TProfile{Resolution: 1080, Languages: []string{"Italian"}}
+	groups = groupMovies(groupMoviesList, p)
+
+	if groups[0].Releases[groups[0].Best].Release != "Barbie.2023.ITA.1080p.BluRay.x265-DDN" {
+		t.Errorf("got best %s for italian profile", groups[0].Releases[groups[0].Best].Release)
+	}
+}
+
+func TestGetProfile(t *testing.T) {
+	p, err := getProfile(`{"resolution": 720, "sources": ["WEB-DL"]}`)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if p.Resolution != 720 || len(p.Sources) != 1 || len(p.Codecs) != len(defaultProfile.Codecs) || p.MaxSize != defaultProfile.MaxSize {
+		t.Errorf("unexpected profile %+v", p)
+	}
+
+	if defaultProfile.Sources[0] != "BluRay" {
+		t.Error("default sources changed")
+	}
+
+	_, err = getProfile("{")
+	if err == nil {
+		t.Error("invalid profile accepted")
+	}
+}
@@ -0,0 +1,236 @@
+package bukanir
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// Handle type, cancellable handle for calls where context.Context is not available, i.e. gomobile
+type Handle struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewHandle returns new handle
+func NewHandle() *Handle {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Handle{ctx, cancel}
+}
+
+// Cancel cancels all calls started with handle
+func (h *Handle) Cancel() {
+	h.cancel()
+}
+
+// Canceled checks if handle is canceled
+func (h *Handle) Canceled() bool {
+	return h.ctx.Err() != nil
+}
+
+// Category returns movies by category
+func (h *Handle) Category(category int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, filter string) (string, error) {
+	f, err := getFilter(filter)
+	if err != nil {
+		return "empty", err
+	}
+
+	return toJSON(defaultClient.Category(h.ctx, category, limit, force, cacheDir, cacheDays, tpbHost, f))
+}
+
+// Search returns movies by search query
+func (h *Handle) Search(query string, limit int, force int, cacheDir string, cacheDays int64, pages int, tpbHost, eztvHost, sortBy, media, filter string) (string, error) {
+	f, err := getFilter(filter)
+	if err != nil {
+		return "empty", err
+	}
+
+	return toJSON(defaultClient.Search(h.ctx, query, limit, force, cacheDir, cacheDays, pages, tpbHost, eztvHost, sortBy, media, f))
+}
+
+// Summary returns movie summary
+func (h *Handle) Summary(id int, category int, season int, episode int) (string, error) {
+	s, err := defaultClient.Summary(h.ctx, id, category, season, episode)
+	if err != nil {
+		return "empty", err
+	}
+
+	return toJSON(s, nil)
+}
+
+// Subtitle returns movie subtitles
+func (h *Handle) Subtitle(movie string, year string, release string, language string, category int, season int, episode int, imdbID string) (string, error) {
+	return toJSON(defaultClient.Subtitle(h.ctx, movie, year, release, language, category, season, episode, imdbID))
+}
+
+// UnzipSubtitle unzip subtitle
+func (h *Handle) UnzipSubtitle(url string, dest string) (string, error) {
+	path, err := defaultClient.UnzipSubtitle(h.ctx, url, dest)
+	if errors.Is(err, ErrNoResults) {
+		return "empty", nil
+	} else if err != nil {
+		return "empty", err
+	}
+
+	return path, nil
+}
+
+// AutoComplete completes search queries
+func (h *Handle) AutoComplete(query string, limit int) (string, error) {
+	return toJSON(defaultClient.AutoComplete(h.ctx, query, limit))
+}
+
+// Popular returns popular movies
+func (h *Handle) Popular() (string, error) {
+	return toJSON(defaultClient.Popular(h.ctx))
+}
+
+// TopRated returns top rated movies
+func (h *Handle) TopRated() (string, error) {
+	return toJSON(defaultClient.TopRated(h.ctx))
+}
+
+// Genres returns all genres
+func (h *Handle) Genres() (string, error) {
+	return toJSON(defaultClient.Genres(h.ctx))
+}
+
+// Genre returns movies by genre
+func (h *Handle) Genre(id int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, filter string) (string, error) {
+	f, err := getFilter(filter)
+	if err != nil {
+		return "empty", err
+	}
+
+	return toJSON(defaultClient.Genre(h.ctx, id, limit, force, cacheDir, cacheDays, tpbHost, f))
+}
+
+// Cast returns movies by cast
+func (h *Handle) Cast(id int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string) (string, error) {
+	return toJSON(defaultClient.Cast(h.ctx, id, limit, force, cacheDir, cacheDays, tpbHost))
+}
+
+// Crew returns movies by crew
+func (h *Handle) Crew(id int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string) (string, error) {
+	return toJSON(defaultClient.Crew(h.ctx, id, limit, force, cacheDir, cacheDays, tpbHost))
+}
+
+// Similar returns similar movies or tv shows that have a torrent
+func (h *Handle) Similar(id int, category int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, filter string) (string, error) {
+	f, err := getFilter(filter)
+	if err != nil {
+		return "empty", err
+	}
+
+	return toJSON(defaultClient.Similar(h.ctx, id, category, limit, force, cacheDir, cacheDays, tpbHost, f))
+}
+
+// Recommendations returns recommended movies or tv shows that have a torrent
+func (h *Handle) Recommendations(id int, category int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, filter string) (string, error) {
+	f, err := getFilter(filter)
+	if err != nil {
+		return "empty", err
+	}
+
+	return toJSON(defaultClient.Recommendations(h.ctx, id, category, limit, force, cacheDir, cacheDays, tpbHost, f))
+}
+
+// UpdateImdbRatings downloads IMDb datasets and rebuilds local index, index that is not older than a week is kept unless force is set
+func (h *Handle) UpdateImdbRatings(force bool) error {
+	return defaultClient.UpdateImdbRatings(h.ctx, force)
+}
+
+// Discover returns movies or tv shows matching discover query that have a torrent
+func (h *Handle) Discover(discover string, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, filter string) (string, error) {
+	var d TDiscover
+	err := json.Unmarshal([]byte(discover), &d)
+	if err != nil {
+		return "empty", err
+	}
+
+	f, err := getFilter(filter)
+	if err != nil {
+		return "empty", err
+	}
+
+	return toJSON(defaultClient.Discover(h.ctx, d, limit, force, cacheDir, cacheDays, tpbHost, f))
+}
+
+// List returns movies and tv shows from TMDB list that have a torrent
+func (h *Handle) List(list string, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, filter string) (string, error) {
+	f, err := getFilter(filter)
+	if err != nil {
+		return "empty", err
+	}
+
+	return toJSON(defaultClient.List(h.ctx, list, limit, force, cacheDir, cacheDays, tpbHost, f))
+}
+
+// SearchPerson returns people matching query
+func (h *Handle) SearchPerson(query string, limit int) (string, error) {
+	return toJSON(defaultClient.SearchPerson(h.ctx, query, limit))
+}
+
+// Person returns person details with combined movie and tv credits
+func (h *Handle) Person(id int) (string, error) {
+	return toJSON(defaultClient.Person(h.ctx, id))
+}
+
+// Collection returns all parts of movie collection in release order, each with the best available torrent
+func (h *Handle) Collection(id int, tpbHost string, filter string) (string, error) {
+	f, err := getFilter(filter)
+	if err != nil {
+		return "empty", err
+	}
+
+	return toJSON(defaultClient.Collection(h.ctx, id, tpbHost, f))
+}
+
+// Certifications returns movie or tv certifications of country, ordered from least to most restrictive
+func (h *Handle) Certifications(country string, tv bool) (string, error) {
+	return toJSON(defaultClient.Certifications(h.ctx, country, tv))
+}
+
+// Trailers returns TMDB videos of movie or tv show
+func (h *Handle) Trailers(id int, category int) (string, error) {
+	return toJSON(defaultClient.Trailers(h.ctx, id, category))
+}
+
+// Trailer returns video stream url in preferred quality, i.e. "720p", stream is one of Trailer streams
+func (h *Handle) Trailer(videoId string, quality string, stream int) (string, error) {
+	uri, err := defaultClient.Trailer(h.ctx, videoId, quality, stream)
+	if err != nil {
+		return "empty", err
+	}
+
+	return uri, nil
+}
+
+// ShowSeasons returns seasons of tv show
+func (h *Handle) ShowSeasons(id int) (string, error) {
+	return toJSON(defaultClient.ShowSeasons(h.ctx, id))
+}
+
+// SeasonEpisodes returns episodes of tv show season with the best available torrents
+func (h *Handle) SeasonEpisodes(id int, season int, pages int, tpbHost, eztvHost, filter string) (string, error) {
+	f, err := getFilter(filter)
+	if err != nil {
+		return "empty", err
+	}
+
+	return toJSON(defaultClient.SeasonEpisodes(h.ctx, id, season, pages, tpbHost, eztvHost, f))
+}
+
+// toJSON returns indented json for typed result, empty results are returned as empty list
+func toJSON(v interface{}, err error) (string, error) {
+	if err != nil && !errors.Is(err, ErrNoResults) {
+		return "empty", err
+	}
+
+	js, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return "empty", err
+	}
+
+	return string(js[:]), nil
+}
@@ -15,7 +15,6 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
@@ -24,11 +23,10 @@ import (
 )
 
 var (
-	reYear    = regexp.MustCompile(`(.*)(19\d{2}|20\d{2})(.*)`)
-	reQuality = regexp.MustCompile(`(.*)(720|1080)p?(.*)`)
-	reTitle1  = regexp.MustCompile(`(.*?)(dvdrip|xvid|dvdscr|brrip|bdrip|divx|klaxxon|hc|webrip|hdrip|camrip|hdtv|eztv|proper|x264|480p|720p|1080p|[\*\{\(\[]?[0-9]{4}).*`)
-	reTitle2  = regexp.MustCompile(`(.*?)\(.*\)(.*)`)
-	reSeason  = regexp.MustCompile(`(?i:s|season)(\d{2})(?i:e|x|episode)(\d{2}).*`)
+	reYear   = regexp.MustCompile(`(.*)(19\d{2}|20\d{2})(.*)`)
+	reTitle1 = regexp.MustCompile(`(.*?)(dvdrip|xvid|dvdscr|brrip|bdrip|divx|klaxxon|hc|webrip|hdrip|camrip|hdtv|eztv|proper|x264|480p|720p|1080p|[\*\{\(\[]?[0-9]{4}).*`)
+	reTitle2 = regexp.MustCompile(`(.*?)\(.*\)(.*)`)
+	reSeason = regexp.MustCompile(`(?i:s|season)(\d{2})(?i:e|x|episode)(\d{2}).*`)
 )
 
 // saveCache saves cache
@@ -98,8 +96,8 @@ func getCache(key string, cacheDir string, days int64) []byte {
 }
 
 // getDocument returns goquery document
-func getDocument(uri string) (*goquery.Document, error) {
-	res, err := getResponse(uri)
+func getDocument(ctx context.Context, uri string) (*goquery.Document, error) {
+	res, err := getResponse(ctx, uri)
 	if err != nil {
 		return nil, err
 	}
@@ -162,89 +160,89 @@ func getClient(torProxy bool) (*http.Client, error) {
 }
 
 // getResponse returns http response
-func getResponse(uri string) (*http.Response, error) {
+func getResponse(ctx context.Context, uri string) (*http.Response, error) {
 	req, err := http.NewRequest("GET", uri, nil)
 	if err != nil {
-		return nil, errors.New(strings.Replace(err.Error(), tmdbApiKey, "xxx", -1))
+		return nil, errors.New(redact(err.Error()))
 	}
 
 	req = req.WithContext(ctx)
 	req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64; rv:36.0) Gecko/20100101 Firefox/36.0")
 
+	if strings.HasPrefix(uri, tmdbApiUrl) {
+		if token := getCredentials().TmdbToken; token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
 	client, err := getClient(strings.Contains(uri, ".onion/"))
 	if err != nil {
 		return nil, err
 	}
 
+	lim := getLimiter(req.URL.Host)
+	err = lim.wait(ctx, req.URL.Host)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := client.Do(req)
 	if err != nil {
-		return nil, errors.New(strings.Replace(err.Error(), tmdbApiKey, "xxx", -1))
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		msg := redact(err.Error())
+		if e, ok := err.(net.Error); ok && e.Timeout() {
+			return nil, fmt.Errorf("%w: %s", errTimeout, msg)
+		}
+		return nil, errors.New(msg)
+	}
+
+	lim.update(res)
+
+	if res.StatusCode == http.StatusUnauthorized {
+		res.Body.Close()
+		return nil, fmt.Errorf("%w: Status Code %d received", errUnauthorized, res.StatusCode)
 	}
 
 	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusTooManyRequests {
+		res.Body.Close()
 		return nil, errors.New(fmt.Sprintf("Status Code %d received", res.StatusCode))
 	}
 
 	return res, nil
 }
 
-// getBody returns response body as byte slice
-func getBody(uri string) ([]byte, error) {
-	var err error
-	var body []byte
-	var res *http.Response
-
-	retry := func(r *http.Response) (*http.Response, error) {
-		sleep, err := strconv.Atoi(r.Header.Get("Retry-After"))
-		if err == nil {
-			if verbose {
-				log.Printf("TMDB: Retry-After: %d, sleeping for %d seconds\n", sleep, sleep+1)
-			}
-
-			select {
-			case <-cancelchan:
-				return nil, errors.New("getBody request canceled")
-			case <-time.After(time.Duration(sleep+1) * time.Second):
-				break
-			}
-
-			return getResponse(uri)
-		}
-
-		return r, nil
-	}
-
-	res, err = getResponse(uri)
+// getBody returns response body as byte slice, requests limited with 429 are retried after limiter backoff
+func getBody(ctx context.Context, uri string) ([]byte, error) {
+	res, err := getResponse(ctx, uri)
 	if err != nil {
 		return nil, err
 	}
 
-	if res.StatusCode == http.StatusTooManyRequests {
-		res, err = retry(res)
+	for n := 0; n < 3 && res.StatusCode == http.StatusTooManyRequests; n++ {
+		res.Body.Close()
+
+		if verbose {
+			log.Printf("RATE: StatusCode %d received, retry %d\n", res.StatusCode, n+1)
+		}
+
+		res, err = getResponse(ctx, uri)
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		if res.StatusCode == http.StatusTooManyRequests {
-			res, err = retry(res)
-			if err != nil {
-				return nil, err
-			}
-
-			if res.StatusCode == http.StatusTooManyRequests {
-				res, err = retry(res)
-				if err != nil {
-					return nil, err
-				}
-			}
-		}
+	if res.StatusCode == http.StatusTooManyRequests {
+		res.Body.Close()
+		return nil, fmt.Errorf("%w: StatusCode %d received", ErrRateLimited, res.StatusCode)
 	}
 
 	if res.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("StatusCode %d received", res.StatusCode)
 	}
 
-	body, err = ioutil.ReadAll(res.Body)
+	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
 		return nil, err
 	}
@@ -298,13 +296,7 @@ func getYear(torrentTitle string) string {
 
 // getQuality returns quality from torrent title
 func getQuality(torrentTitle string) string {
-	quality := ""
-	re := reQuality.FindAllStringSubmatch(torrentTitle, -1)
-	if len(re) > 0 {
-		quality = re[0][2]
-	}
-
-	return quality
+	return strings.TrimSuffix(parseRelease(torrentTitle).Resolution, "p")
 }
 
 // getSeason returns tv show season from torrent title
@@ -349,9 +341,9 @@ func getCastIds(res []tmdbCast) []int {
 	return cast
 }
 
-// getVideo returns youtube trailer from tmdb []tmdbVideo
-func getVideo(res []tmdbVideo) (video string) {
-	for _, c := range res {
+// getVideo returns youtube trailer from tmdb []tmdbVideo, trailers in language are preferred
+func getVideo(res []tmdbVideo, language string) (video string) {
+	for _, c := range sortVideos(res, language) {
 		if strings.ToLower(c.Site) == "youtube" && strings.ToLower(c.Type) == "trailer" {
 			video = c.Key
 			return
@@ -371,6 +363,34 @@ func getGenre(res []tmdbGenre) []string {
 	return genre
 }
 
+// getLanguages returns language names from []tmdbLanguage
+func getLanguages(res []tmdbLanguage) []string {
+	var languages []string
+	for _, l := range res {
+		name := l.English_name
+		if name == "" {
+			name = l.Iso_639_1
+		}
+		languages = append(languages, name)
+	}
+
+	return languages
+}
+
+// getCountries returns country names from []tmdbCountry, origin country codes are used when there are none
+func getCountries(res []tmdbCountry, origin []string) []string {
+	var countries []string
+	for _, c := range res {
+		countries = append(countries, c.Name)
+	}
+
+	if len(countries) == 0 {
+		countries = origin
+	}
+
+	return countries
+}
+
 // getDirector returns director string from []tmdbCrew
 func getDirector(res []tmdbCrew) string {
 	var director string
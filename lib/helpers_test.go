@@ -0,0 +1,48 @@
+package bukanir
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetBodyCancel(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := getBody(ctx, ts.URL)
+	if err != context.Canceled {
+		t.Errorf("got %v, want %v", err, context.Canceled)
+	}
+
+	if time.Since(start) > 5*time.Second {
+		t.Error("Retry-After sleep was not canceled")
+	}
+}
+
+func TestGetBodyRateLimited(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	_, err := getBody(context.Background(), ts.URL)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("got %v, want %v", err, ErrRateLimited)
+	}
+
+	if errors.Is(providerError("tpb", err), ErrProviderUnavailable) {
+		t.Error("rate limited provider reported as unavailable")
+	}
+}
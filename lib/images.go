@@ -0,0 +1,344 @@
+package bukanir
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Image cache directory and quota, images are cached in temporary directory when directory is not set
+var (
+	imagesMu    sync.Mutex
+	imagesDir   string
+	imagesQuota int64
+	imagesSize  int64 = -1
+	imagesUrl   string
+)
+
+var (
+	reImageSize = regexp.MustCompile(`^(w[0-9]+|h[0-9]+|original)$`)
+	reImagePath = regexp.MustCompile(`^[A-Za-z0-9_\-]+\.(jpg|png)$`)
+)
+
+// Largest width images are resized to
+const maxImageWidth = 3840
+
+// SetImageCache sets image cache directory and quota in megabytes, zero quota is unlimited
+func SetImageCache(dir string, quota int) {
+	imagesMu.Lock()
+	defer imagesMu.Unlock()
+
+	imagesDir = dir
+	imagesQuota = int64(quota) * 1024 * 1024
+	imagesSize = -1
+}
+
+// StartImageServer starts local HTTP server for images and trailers on address, i.e. 127.0.0.1:0, and returns its url
+func StartImageServer(addr string) (string, error) {
+	l, err := net.Listen("tcp4", addr)
+	if err != nil {
+		return "", err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/images/", imageHandler())
+	mux.Handle("/trailers/", trailerHandler())
+
+	go http.Serve(l, mux)
+
+	imagesMu.Lock()
+	imagesUrl = "http://" + l.Addr().String()
+	imagesMu.Unlock()
+
+	if verbose {
+		log.Printf("IMG: Listening HTTP on %s...\n", l.Addr().String())
+	}
+
+	return imagesUrl, nil
+}
+
+// LocalImage returns url of TMDB image on local image server, url is not changed when server is not started
+func LocalImage(uri string) string {
+	imagesMu.Lock()
+	base := imagesUrl
+	imagesMu.Unlock()
+
+	if base == "" {
+		return uri
+	}
+
+	n := strings.Index(uri, "/t/p/")
+	if n == -1 {
+		return uri
+	}
+
+	return base + "/images/" + uri[n+len("/t/p/"):]
+}
+
+// imageHandler serves images from /images/{size}/{path}, TMDB sizes are downloaded and other widths are resized
+func imageHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/images/"), "/")
+		if len(parts) != 2 || !reImageSize.MatchString(parts[0]) || !reImagePath.MatchString(parts[1]) {
+			http.NotFound(w, r)
+			return
+		}
+
+		file, err := getImage(r.Context(), parts[0], parts[1])
+		if err != nil {
+			log.Printf("ERROR: getImage %s/%s: %s\n", parts[0], parts[1], err.Error())
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "max-age=604800")
+		http.ServeFile(w, r, file)
+	})
+}
+
+// getImage returns file of cached image, image is downloaded and resized if it is not cached
+func getImage(ctx context.Context, size, path string) (string, error) {
+	imagesMu.Lock()
+	dir := imagesDir
+	imagesMu.Unlock()
+
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "bukanir-images")
+	}
+
+	file := filepath.Join(dir, size, path)
+	if _, err := os.Stat(file); err == nil {
+		now := time.Now()
+		os.Chtimes(file, now, now)
+		return file, nil
+	}
+
+	md := NewTmdb(ctx)
+	config, err := md.GetConfig()
+	if err != nil {
+		return "", err
+	}
+
+	tmdbSize, width := imageSize(config.Images, size)
+
+	body, err := getBody(ctx, config.Images.Secure_base_url+tmdbSize+"/"+path)
+	if err != nil {
+		return "", err
+	}
+
+	if width > 0 {
+		body, err = resizeImage(body, path, width)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = os.MkdirAll(filepath.Dir(file), 0777)
+	if err != nil {
+		return "", err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(file), "tmp")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = tmp.Write(body)
+	tmp.Close()
+	if err == nil {
+		err = os.Rename(tmp.Name(), file)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	addImage(dir, int64(len(body)))
+
+	return file, nil
+}
+
+// imageSize returns TMDB size to download for requested size and width to resize to, zero width is not resized.
+// Widths that are not TMDB sizes are resized from the next larger size.
+func imageSize(config tmdbImageConfig, size string) (string, int) {
+	sizes := make(map[string]bool)
+	for _, s := range [][]string{config.Poster_sizes, config.Backdrop_sizes, config.Profile_sizes, config.Still_sizes, config.Logo_sizes} {
+		for _, sz := range s {
+			sizes[sz] = true
+		}
+	}
+
+	if sizes[size] || size == "original" || size[0] != 'w' {
+		return size, 0
+	}
+
+	width, _ := strconv.Atoi(size[1:])
+	if width <= 0 || width > maxImageWidth {
+		return "original", 0
+	}
+
+	widths := make([]int, 0)
+	for sz := range sizes {
+		if w, err := strconv.Atoi(strings.TrimPrefix(sz, "w")); err == nil && sz[0] == 'w' {
+			widths = append(widths, w)
+		}
+	}
+	sort.Ints(widths)
+
+	for _, w := range widths {
+		if w >= width {
+			return "w" + strconv.Itoa(w), width
+		}
+	}
+
+	return "original", width
+}
+
+// resizeImage resizes jpeg or png image to width, images are not enlarged
+func resizeImage(body []byte, path string, width int) ([]byte, error) {
+	var src image.Image
+	var err error
+
+	isPng := strings.HasSuffix(path, ".png")
+	if isPng {
+		src, err = png.Decode(bytes.NewReader(body))
+	} else {
+		src, err = jpeg.Decode(bytes.NewReader(body))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	b := src.Bounds()
+	if b.Dx() <= width {
+		return body, nil
+	}
+
+	height := b.Dy() * width / b.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	// Box filter, each destination pixel is average of source pixels it covers
+	for y := 0; y < height; y++ {
+		y0, y1 := b.Min.Y+y*b.Dy()/height, b.Min.Y+(y+1)*b.Dy()/height
+		for x := 0; x < width; x++ {
+			x0, x1 := b.Min.X+x*b.Dx()/width, b.Min.X+(x+1)*b.Dx()/width
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a, n = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca), n+1
+				}
+			}
+
+			if n > 0 {
+				dst.Set(x, y, color.RGBA64{uint16(r / n), uint16(g / n), uint16(bl / n), uint16(a / n)})
+			}
+		}
+	}
+
+	return encodeImage(dst, isPng)
+}
+
+// encodeImage encodes image as png or jpeg
+func encodeImage(img image.Image, isPng bool) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if isPng {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	}
+
+	return buf.Bytes(), err
+}
+
+// addImage adds size of new image to image cache size
+func addImage(dir string, size int64) {
+	imagesMu.Lock()
+	defer imagesMu.Unlock()
+
+	addCached(dir, imagesQuota, &imagesSize, size)
+}
+
+// addCached adds size of new file to cache size, least recently used files are removed when cache is over quota.
+// Negative cache size is read from directory, lock of the cache must be held.
+func addCached(dir string, quota int64, cached *int64, size int64) {
+	if quota <= 0 {
+		return
+	}
+
+	if *cached < 0 {
+		*cached = 0
+		for _, f := range cachedFiles(dir) {
+			*cached += f.Size()
+		}
+	} else {
+		*cached += size
+	}
+
+	if *cached <= quota {
+		return
+	}
+
+	files := cachedFiles(dir)
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+
+	// Evict to 90% of quota, so eviction does not run for every new file
+	for _, f := range files {
+		if *cached <= quota*9/10 {
+			break
+		}
+
+		err := os.Remove(f.path)
+		if err != nil {
+			log.Printf("ERROR: Remove %s: %s\n", f.path, err.Error())
+			continue
+		}
+		*cached -= f.Size()
+	}
+
+	if verbose {
+		log.Printf("BUK: Cache %s size %d MB\n", dir, *cached/1024/1024)
+	}
+}
+
+// cachedFile type
+type cachedFile struct {
+	os.FileInfo
+	path string
+}
+
+// cachedFiles returns all files in cache directory
+func cachedFiles(dir string) []cachedFile {
+	files := make([]cachedFile, 0)
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			files = append(files, cachedFile{info, path})
+		}
+		return nil
+	})
+
+	return files
+}
@@ -0,0 +1,103 @@
+package bukanir
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestImageSize(t *testing.T) {
+	config := tmdbImageConfig{
+		Poster_sizes:   []string{"w92", "w154", "w185", "w342", "w500", "w780", "original"},
+		Backdrop_sizes: []string{"w300", "w780", "w1280", "original"},
+		Profile_sizes:  []string{"w45", "h632", "original"},
+	}
+
+	tests := []struct {
+		size  string
+		want  string
+		width int
+	}{
+		{"w342", "w342", 0},
+		{"h632", "h632", 0},
+		{"original", "original", 0},
+		{"w200", "w300", 200},
+		{"w1000", "w1280", 1000},
+		{"w1920", "original", 1920},
+		{"w10000", "original", 0},
+	}
+
+	for _, tt := range tests {
+		size, width := imageSize(config, tt.size)
+		if size != tt.want || width != tt.width {
+			t.Errorf("imageSize(%q) = %q, %d, want %q, %d", tt.size, size, width, tt.want, tt.width)
+		}
+	}
+}
+
+func TestResizeImage(t *testing.T) {
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 100, 50)), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := resizeImage(buf.Bytes(), "test.jpg", 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if img.Bounds().Dx() != 20 || img.Bounds().Dy() != 10 {
+		t.Errorf("size %v, want 20x10", img.Bounds().Size())
+	}
+
+	body, err = resizeImage(buf.Bytes(), "test.jpg", 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(body, buf.Bytes()) {
+		t.Error("image enlarged, want original")
+	}
+}
+
+func TestAddCached(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	for n, name := range []string{"old", "mid", "new"} {
+		file := filepath.Join(dir, name)
+		ioutil.WriteFile(file, make([]byte, 400), 0644)
+		mtime := now.Add(time.Duration(n-3) * time.Hour)
+		os.Chtimes(file, mtime, mtime)
+	}
+
+	size := int64(-1)
+	addCached(dir, 1000, &size, 400)
+
+	if _, err := os.Stat(filepath.Join(dir, "old")); !os.IsNotExist(err) {
+		t.Error("least recently used file is not removed")
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "new")); err != nil {
+		t.Error("recently used file is removed")
+	}
+
+	if size != 800 {
+		t.Errorf("cache size %d, want 800", size)
+	}
+}
@@ -0,0 +1,404 @@
+package bukanir
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IMDb dataset urls
+const (
+	imdbRatingsUrl = "https://datasets.imdbws.com/title.ratings.tsv.gz"
+	imdbBasicsUrl  = "https://datasets.imdbws.com/title.basics.tsv.gz"
+)
+
+// IMDb index file name and age after which UpdateImdbRatings downloads datasets again
+const (
+	imdbIndexFile = "imdb.tsv.gz"
+	imdbIndexTTL  = 7 * 24 * time.Hour
+)
+
+// Minimum number of votes for title to be indexed by title and year
+const imdbMinVotes = 10
+
+// Title types that are indexed, by media
+var imdbTypes = map[string]string{
+	"movie":        "movie",
+	"tvMovie":      "movie",
+	"video":        "movie",
+	"tvSeries":     "tv",
+	"tvMiniSeries": "tv",
+}
+
+// imdbRating type
+type imdbRating struct {
+	rating float64
+	votes  int
+}
+
+// imdbIndex type, ratings by IMDb id without tt prefix and IMDb ids by media, title and year
+type imdbIndex struct {
+	ratings map[string]imdbRating
+	titles  map[string]string
+}
+
+var (
+	imdbMu     sync.Mutex
+	imdbDir    string
+	imdbLoaded *imdbIndex
+)
+
+// SetImdbDir sets directory of local IMDb ratings index, empty string disables IMDb ratings
+func SetImdbDir(dir string) {
+	imdbMu.Lock()
+	defer imdbMu.Unlock()
+
+	imdbDir = dir
+	imdbLoaded = nil
+}
+
+// UpdateImdbRatings downloads IMDb datasets and rebuilds local index, index that is not older than a week is kept unless force is set
+func (c *Client) UpdateImdbRatings(ctx context.Context, force bool) error {
+	imdbMu.Lock()
+	dir := imdbDir
+	imdbMu.Unlock()
+
+	if dir == "" {
+		return fmt.Errorf("IMDb directory is not set")
+	}
+
+	file := filepath.Join(dir, imdbIndexFile)
+	if info, err := os.Stat(file); err == nil && !force && time.Since(info.ModTime()) < imdbIndexTTL {
+		return nil
+	}
+
+	ratings := make(map[string]imdbRating)
+	err := imdbDataset(ctx, imdbRatingsUrl, func(fields []string) {
+		if len(fields) < 3 {
+			return
+		}
+
+		rating, err1 := strconv.ParseFloat(fields[1], 64)
+		votes, err2 := strconv.Atoi(fields[2])
+		if err1 == nil && err2 == nil {
+			ratings[fields[0]] = imdbRating{rating, votes}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dir, 0777)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(dir, "tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	gz := gzip.NewWriter(tmp)
+	w := bufio.NewWriter(gz)
+
+	n := 0
+	err = imdbDataset(ctx, imdbBasicsUrl, func(fields []string) {
+		if len(fields) < 6 {
+			return
+		}
+
+		media, ok := imdbTypes[fields[1]]
+		if !ok {
+			return
+		}
+
+		r, ok := ratings[fields[0]]
+		if !ok {
+			return
+		}
+
+		year := fields[5]
+		if year == `\N` {
+			year = ""
+		}
+
+		fmt.Fprintf(w, "%s\t%.1f\t%d\t%s\t%s\t%s\t%s\n", strings.TrimPrefix(fields[0], "tt"), r.rating, r.votes, media, year, fields[2], fields[3])
+		n++
+	})
+
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = gz.Close()
+	}
+	tmp.Close()
+
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmp.Name(), file)
+	if err != nil {
+		return err
+	}
+
+	if verbose {
+		log.Printf("IMDB: Indexed %d titles\n", n)
+	}
+
+	imdbMu.Lock()
+	imdbLoaded = nil
+	imdbMu.Unlock()
+
+	return nil
+}
+
+// imdbDataset downloads gzipped tsv dataset and calls fn for each row, header row is skipped.
+// Datasets are large, so only connection and response headers have timeouts.
+func imdbDataset(ctx context.Context, uri string, fn func(fields []string)) error {
+	req, err := http.NewRequest("GET", uri, nil)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext:           (&net.Dialer{Timeout: 30 * time.Second}).DialContext,
+			ResponseHeaderTimeout: 30 * time.Second,
+		},
+	}
+
+	res, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return fmt.Errorf("%s: status %d", uri, res.StatusCode)
+	}
+
+	return readTsv(res.Body, true, fn)
+}
+
+// readTsv reads gzipped tsv and calls fn for each row
+func readTsv(r io.Reader, header bool, fn func(fields []string)) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		if header {
+			header = false
+			continue
+		}
+
+		fn(strings.Split(scanner.Text(), "\t"))
+	}
+
+	return scanner.Err()
+}
+
+// getImdbIndex returns local IMDb index, index is loaded on first use
+func getImdbIndex() *imdbIndex {
+	imdbMu.Lock()
+	defer imdbMu.Unlock()
+
+	if imdbLoaded != nil || imdbDir == "" {
+		return imdbLoaded
+	}
+
+	file := filepath.Join(imdbDir, imdbIndexFile)
+	f, err := os.Open(file)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("ERROR: Open %s: %s\n", file, err.Error())
+		}
+		imdbLoaded = &imdbIndex{}
+		return imdbLoaded
+	}
+	defer f.Close()
+
+	idx := &imdbIndex{make(map[string]imdbRating), make(map[string]string)}
+	err = readTsv(f, false, func(fields []string) {
+		if len(fields) < 7 {
+			return
+		}
+
+		rating, _ := strconv.ParseFloat(fields[1], 64)
+		votes, _ := strconv.Atoi(fields[2])
+		idx.ratings[fields[0]] = imdbRating{rating, votes}
+
+		if votes < imdbMinVotes {
+			return
+		}
+
+		for _, title := range fields[5:7] {
+			key := imdbKey(fields[3], title, fields[4])
+			if id, ok := idx.titles[key]; !ok || idx.ratings[id].votes < votes {
+				idx.titles[key] = fields[0]
+			}
+		}
+	})
+	if err != nil {
+		log.Printf("ERROR: Read %s: %s\n", file, err.Error())
+	}
+
+	imdbLoaded = idx
+	return imdbLoaded
+}
+
+// imdbKey returns index key for media, title and year
+func imdbKey(media, title, year string) string {
+	return media + "|" + normalizeTitle(title) + "|" + year
+}
+
+// imdbById returns IMDb rating and votes by IMDb id, with or without tt prefix
+func imdbById(id string) (float64, int) {
+	idx := getImdbIndex()
+	if idx == nil || idx.ratings == nil {
+		return 0, 0
+	}
+
+	r := idx.ratings[strings.TrimPrefix(id, "tt")]
+	return r.rating, r.votes
+}
+
+// imdbByTitle returns IMDb rating and votes by title and year, tv shows are matched by first air year
+func imdbByTitle(title, year string, tv bool) (float64, int) {
+	idx := getImdbIndex()
+	if idx == nil || idx.titles == nil {
+		return 0, 0
+	}
+
+	media := "movie"
+	if tv {
+		media = "tv"
+	}
+
+	id, ok := idx.titles[imdbKey(media, title, year)]
+	if !ok {
+		return 0, 0
+	}
+
+	r := idx.ratings[id]
+	return r.rating, r.votes
+}
+
+// setImdbRatings sets IMDb ratings of movies that do not have them, ratings are looked up once per title
+func (c *call) setImdbRatings(movs []TMovie) {
+	type title struct {
+		id         int
+		tv         bool
+		name, year string
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	th := make(chan int, runtime.NumCPU())
+	ratings := make(map[title]TMovie)
+
+	for _, m := range movs {
+		if m.ImdbVotes != 0 || m.ImdbId != "" {
+			continue
+		}
+
+		t := title{m.Id, m.Category == CategoryTV || m.Category == CategoryHDTV, m.Title, m.Year}
+
+		mu.Lock()
+		_, ok := ratings[t]
+		ratings[t] = TMovie{}
+		mu.Unlock()
+
+		if ok {
+			continue
+		}
+
+		th <- 1
+		wg.Add(1)
+		go func(t title) {
+			defer func() {
+				<-th
+				wg.Done()
+			}()
+
+			var r TMovie
+			r.ImdbId, r.ImdbRating, r.ImdbVotes = c.imdbRating(t.id, t.tv, t.name, t.year)
+
+			mu.Lock()
+			ratings[t] = r
+			mu.Unlock()
+		}(t)
+	}
+	wg.Wait()
+
+	for n := range movs {
+		m := &movs[n]
+		if r, ok := ratings[title{m.Id, m.Category == CategoryTV || m.Category == CategoryHDTV, m.Title, m.Year}]; ok && m.ImdbVotes == 0 && m.ImdbId == "" {
+			m.ImdbId, m.ImdbRating, m.ImdbVotes = r.ImdbId, r.ImdbRating, r.ImdbVotes
+		}
+	}
+}
+
+// imdbRating returns IMDb id, rating and votes of TMDB movie or tv show, title and year are used when IMDb id is not known
+func (c *call) imdbRating(id int, tv bool, title, year string) (string, float64, int) {
+	idx := getImdbIndex()
+	if idx == nil || len(idx.ratings) == 0 {
+		return "", 0, 0
+	}
+
+	imdbId := c.imdbId(id, tv)
+	if imdbId == "" {
+		rating, votes := imdbByTitle(title, year, tv)
+		return "", rating, votes
+	}
+
+	rating, votes := imdbById(imdbId)
+	return imdbId, rating, votes
+}
+
+// imdbId returns IMDb id of TMDB movie or tv show without tt prefix, empty when it is not known
+func (c *call) imdbId(id int, tv bool) string {
+	if id <= 0 {
+		return ""
+	}
+
+	md := NewTmdb(c.ctx)
+
+	if tv {
+		ext, err := md.GetTvExternals(strconv.Itoa(id))
+		if err != nil {
+			log.Printf("ERROR: GetTvExternals: %v\n", err.Error())
+			return ""
+		}
+		return strings.TrimPrefix(ext.Imdb_id, "tt")
+	}
+
+	res, err := md.GetMovieDetails(strconv.Itoa(id))
+	if err != nil {
+		log.Printf("ERROR: GetMovieDetails: %v\n", err.Error())
+		return ""
+	}
+
+	return strings.TrimPrefix(res.Imdb_id, "tt")
+}
@@ -0,0 +1,67 @@
+package bukanir
+
+import (
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestImdbIndex(t *testing.T) {
+	dir, err := ioutil.TempDir("", "imdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	f, err := os.Create(filepath.Join(dir, imdbIndexFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gz := gzip.NewWriter(f)
+	gz.Write([]byte("1160419\t8.0\t950000\tmovie\t2021\tDune\tDune\n" +
+		"0087182\t6.3\t190000\tmovie\t1984\tDune\tDune\n" +
+		"0944947\t9.2\t2300000\ttv\t2011\tGame of Thrones\tGame of Thrones\n" +
+		"0211915\t8.3\t780000\tmovie\t2001\tAmélie\tLe fabuleux destin d'Amélie Poulain\n"))
+	gz.Close()
+	f.Close()
+
+	SetImdbDir(dir)
+	defer SetImdbDir("")
+
+	if rating, votes := imdbById("tt1160419"); rating != 8.0 || votes != 950000 {
+		t.Errorf("imdbById = %v, %v", rating, votes)
+	}
+
+	if rating, _ := imdbByTitle("Dune", "1984", false); rating != 6.3 {
+		t.Errorf("imdbByTitle Dune 1984 = %v, want 6.3", rating)
+	}
+
+	if rating, _ := imdbByTitle("Le Fabuleux Destin d'Amélie Poulain", "2001", false); rating != 8.3 {
+		t.Errorf("imdbByTitle original title = %v, want 8.3", rating)
+	}
+
+	if _, votes := imdbByTitle("Game of Thrones", "2011", false); votes != 0 {
+		t.Errorf("tv show matched as movie")
+	}
+
+	movs := []TMovie{
+		{Title: "Dune", Year: "2021", Category: CategoryHDmovies},
+		{Title: "Dune", Year: "1984", Category: CategoryMovies},
+		{Title: "Game of Thrones", Year: "2011", Category: CategoryHDTV},
+		{Title: "Unknown", Year: "2020", Category: CategoryMovies},
+	}
+	newCall(context.Background()).setImdbRatings(movs)
+
+	filtered := filterMovies(movs, TFilter{MinImdbRating: 7, MinImdbVotes: 500000})
+	if len(filtered) != 2 || filtered[0].Year != "2021" || filtered[1].Title != "Game of Thrones" {
+		t.Errorf("filterMovies = %+v", filtered)
+	}
+
+	if len(filterMovies(movs, defaultFilter)) != len(movs) {
+		t.Errorf("default filter dropped movies")
+	}
+}
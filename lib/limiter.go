@@ -0,0 +1,197 @@
+package bukanir
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// limiter type, token bucket rate limiter for a single host, shared by all calls
+type limiter struct {
+	mu sync.Mutex
+
+	requests int
+	window   time.Duration
+
+	tokens  float64
+	last    time.Time
+	blocked time.Time
+	backoff time.Duration
+
+	stats limiterStats
+}
+
+// limiterStats type
+type limiterStats struct {
+	requests int
+	waits    int
+	waited   time.Duration
+	limited  int
+}
+
+// Default limits, requests per window by host, hosts without limit are only limited by their responses
+var defaultLimits = map[string]struct {
+	requests int
+	window   time.Duration
+}{
+	"api.themoviedb.org": {40, 10 * time.Second},
+}
+
+// Maximum backoff for 429 responses without Retry-After
+const maxBackoff = 30 * time.Second
+
+var (
+	limitersMu sync.Mutex
+	limiters   = make(map[string]*limiter)
+)
+
+// SetRateLimit sets number of requests allowed for host in window of seconds, zero requests removes the limit
+func SetRateLimit(host string, requests int, seconds int) {
+	l := getLimiter(host)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.requests = requests
+	l.window = time.Duration(seconds) * time.Second
+	l.tokens = float64(requests)
+	l.last = time.Now()
+}
+
+// getLimiter returns limiter for host
+func getLimiter(host string) *limiter {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+
+	l, ok := limiters[host]
+	if !ok {
+		l = &limiter{last: time.Now()}
+		if d, ok := defaultLimits[host]; ok {
+			l.requests = d.requests
+			l.window = d.window
+			l.tokens = float64(d.requests)
+		}
+		limiters[host] = l
+	}
+
+	return l
+}
+
+// wait blocks until request is allowed or context is done
+func (l *limiter) wait(ctx context.Context, host string) error {
+	for {
+		l.mu.Lock()
+
+		now := time.Now()
+		delay := l.blocked.Sub(now)
+
+		if delay <= 0 && l.requests > 0 && l.window > 0 {
+			rate := float64(l.requests) / l.window.Seconds()
+			l.tokens += now.Sub(l.last).Seconds() * rate
+			if l.tokens > float64(l.requests) {
+				l.tokens = float64(l.requests)
+			}
+			l.last = now
+
+			if l.tokens < 1 {
+				delay = time.Duration((1 - l.tokens) / rate * float64(time.Second))
+			} else {
+				l.tokens--
+			}
+		}
+
+		if delay <= 0 {
+			l.stats.requests++
+			l.mu.Unlock()
+			return nil
+		}
+
+		l.stats.waits++
+		l.stats.waited += delay
+		l.mu.Unlock()
+
+		if verbose {
+			log.Printf("RATE: %s: waiting %s\n", host, delay.Round(time.Millisecond))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// update adapts limiter to rate limit headers of response
+func (l *limiter) update(res *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+
+	if res.StatusCode != http.StatusTooManyRequests {
+		l.backoff = 0
+
+		remaining, err := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining"))
+		if err != nil {
+			return
+		}
+
+		if remaining <= 0 {
+			reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64)
+			if err == nil && time.Unix(reset, 0).After(l.blocked) {
+				l.blocked = time.Unix(reset, 0)
+			}
+		} else if l.requests > 0 && float64(remaining) < l.tokens {
+			l.tokens = float64(remaining)
+		}
+
+		return
+	}
+
+	l.stats.limited++
+
+	if sleep, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+		l.blocked = now.Add(time.Duration(sleep) * time.Second)
+		return
+	}
+
+	if reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil && time.Unix(reset, 0).After(now) {
+		l.blocked = time.Unix(reset, 0)
+		return
+	}
+
+	if l.backoff == 0 {
+		l.backoff = time.Second
+	} else if l.backoff < maxBackoff {
+		l.backoff *= 2
+	}
+
+	l.blocked = now.Add(l.backoff)
+}
+
+// logLimiters logs statistics of all limiters
+func logLimiters() {
+	limitersMu.Lock()
+	hosts := make([]string, 0, len(limiters))
+	for host := range limiters {
+		hosts = append(hosts, host)
+	}
+	limitersMu.Unlock()
+
+	sort.Strings(hosts)
+
+	for _, host := range hosts {
+		l := getLimiter(host)
+
+		l.mu.Lock()
+		s := l.stats
+		l.mu.Unlock()
+
+		log.Printf("RATE: %s: requests %d, waits %d, waited %s, limited %d\n", host, s.requests, s.waits, s.waited.Round(time.Millisecond), s.limited)
+	}
+}
@@ -0,0 +1,43 @@
+package bukanir
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	host := "limiter.test"
+	SetRateLimit(host, 2, 1)
+
+	l := getLimiter(host)
+	ctx := context.Background()
+
+	start := time.Now()
+	for n := 0; n < 3; n++ {
+		err := l.wait(ctx, host)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if time.Since(start) < 400*time.Millisecond {
+		t.Errorf("third request was not delayed, took %s", time.Since(start))
+	}
+
+	res := &http.Response{StatusCode: http.StatusTooManyRequests, Header: make(http.Header)}
+	res.Header.Set("Retry-After", "10")
+	l.update(res)
+
+	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+
+	if err := l.wait(ctx, host); err != context.DeadlineExceeded {
+		t.Errorf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if l.stats.limited != 1 {
+		t.Errorf("got %d limited responses, want 1", l.stats.limited)
+	}
+}
@@ -0,0 +1,105 @@
+package bukanir
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// TMDB list paths, tv lists are marked as tv, trending lists have media type of each result
+var tmdbLists = map[string]struct {
+	path string
+	tv   bool
+}{
+	ListTrendingDay:  {"trending/all/day", false},
+	ListTrendingWeek: {"trending/all/week", false},
+	ListNowPlaying:   {"movie/now_playing", false},
+	ListUpcoming:     {"movie/upcoming", false},
+	ListAiringToday:  {"tv/airing_today", true},
+	ListOnTheAir:     {"tv/on_the_air", true},
+}
+
+// List returns movies and tv shows from TMDB list that have a torrent
+func (c *Client) List(ctx context.Context, list string, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, f TFilter) ([]TMovie, error) {
+	if _, ok := tmdbLists[list]; !ok {
+		return make([]TMovie, 0), fmt.Errorf("unknown list %q", list)
+	}
+
+	key := "list" + list + f.key() + parentalKey() + localeKey()
+	if force != 1 {
+		movs := getMoviesCache(key, cacheDir, cacheDays)
+		if movs != nil {
+			return movs, nil
+		}
+	}
+
+	cl := newCall(ctx)
+
+	if tpbHost == "" {
+		tpbHost = getTpbHost()
+	} else {
+		if verbose {
+			log.Printf("TPB: Using host %s\n", tpbHost)
+		}
+	}
+
+	cl.wg.Add(1)
+	go cl.tmdbList(list, limit, tpbHost, f)
+	cl.wg.Wait()
+
+	return c.finish(cl, key, cacheDir, f)
+}
+
+// tmdbList TMDB list, only titles with a torrent are kept
+func (c *call) tmdbList(list string, limit int, tpbHost string, f TFilter) {
+	defer func() {
+		c.wg.Done()
+		if r := recover(); r != nil {
+			log.Print("TMDB: Recovered in tmdbList")
+		}
+	}()
+
+	md := NewTmdb(c.ctx)
+	config, err := md.GetConfig()
+	if err != nil {
+		log.Printf("ERROR: TMDB GetConfig: %s\n", err.Error())
+		c.fail(metadataError(err))
+		return
+	}
+
+	if len(config.Images.Poster_sizes) < 5 {
+		return
+	}
+
+	l := tmdbLists[list]
+
+	var m []tmdbResult
+	for n := 1; n < 4; n++ {
+		mp, err := md.List(l.path, n)
+		if err != nil {
+			log.Printf("ERROR: List %s: %v\n", list, err.Error())
+			c.fail(metadataError(err))
+			return
+		}
+
+		for _, r := range mp.Results {
+			if l.tv {
+				r.Media_type = "tv"
+			}
+
+			if r.Media_type == "movie" || r.Media_type == "tv" || r.Media_type == "" {
+				m = append(m, r)
+			}
+		}
+
+		if n >= mp.Total_pages {
+			break
+		}
+	}
+
+	if limit > 0 && limit < len(m) {
+		m = m[0:limit]
+	}
+
+	c.withTorrents(m, tpbHost, f, config)
+}
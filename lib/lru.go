@@ -0,0 +1,78 @@
+package bukanir
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// lru type, least recently used cache with per entry expiry, safe for concurrent use
+type lru struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+// lruEntry type
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// newLRU returns new cache that holds up to size entries
+func newLRU(size int) *lru {
+	return &lru{size: size, ll: list.New(), items: make(map[string]*list.Element)}
+}
+
+// Get returns value for key, expired entries are removed
+func (l *lru) Get(key string) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := e.Value.(*lruEntry)
+	if time.Now().After(entry.expires) {
+		l.ll.Remove(e)
+		delete(l.items, key)
+		return nil, false
+	}
+
+	l.ll.MoveToFront(e)
+	return entry.value, true
+}
+
+// Add adds value that expires after ttl, oldest entry is evicted when cache is full
+func (l *lru) Add(key string, value []byte, ttl time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if e, ok := l.items[key]; ok {
+		entry := e.Value.(*lruEntry)
+		entry.value = value
+		entry.expires = time.Now().Add(ttl)
+		l.ll.MoveToFront(e)
+		return
+	}
+
+	l.items[key] = l.ll.PushFront(&lruEntry{key, value, time.Now().Add(ttl)})
+
+	if l.ll.Len() > l.size {
+		e := l.ll.Back()
+		l.ll.Remove(e)
+		delete(l.items, e.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns number of entries
+func (l *lru) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.ll.Len()
+}
@@ -0,0 +1,32 @@
+package bukanir
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	l := newLRU(2)
+
+	l.Add("a", []byte("1"), time.Hour)
+	l.Add("b", []byte("2"), time.Hour)
+
+	if _, ok := l.Get("a"); !ok {
+		t.Error("a should be cached")
+	}
+
+	l.Add("c", []byte("3"), time.Hour)
+
+	if _, ok := l.Get("b"); ok {
+		t.Error("b should be evicted as least recently used")
+	}
+
+	if l.Len() != 2 {
+		t.Errorf("got %d entries, want 2", l.Len())
+	}
+
+	l.Add("d", []byte("4"), -time.Second)
+	if _, ok := l.Get("d"); ok {
+		t.Error("d should be expired")
+	}
+}
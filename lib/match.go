@@ -0,0 +1,219 @@
+package bukanir
+
+import (
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Match confidence thresholds, matches below minConfidence are dropped and below verifiedConfidence are unverified
+const (
+	minConfidence      = 0.45
+	verifiedConfidence = 0.7
+)
+
+// Weights of match confidence components, they sum to 1
+const (
+	matchTitle      = 0.6
+	matchYear       = 0.25
+	matchPopularity = 0.1
+	matchType       = 0.05
+)
+
+// Number of best candidates checked against alternative titles
+const matchAlternatives = 3
+
+// tmdbMatch type
+type tmdbMatch struct {
+	result     tmdbResult
+	titles     []string
+	confidence float64
+}
+
+// match returns TMDB result that best matches torrent and match confidence, alternative titles
+// are fetched only for best candidates when no candidate is verified by its titles
+func (t *tmdb) match(tr TTorrent, results []tmdbResult, tv bool) (tmdbResult, float64) {
+	if len(results) == 0 {
+		return tmdbResult{}, 0
+	}
+
+	var popularity float64
+	for _, r := range results {
+		if r.Popularity > popularity {
+			popularity = r.Popularity
+		}
+	}
+
+	matches := make([]tmdbMatch, 0, len(results))
+	for _, r := range results {
+		titles := []string{r.Title, r.Original_title}
+		if tv {
+			titles = []string{r.Name, r.Original_name}
+		}
+
+		m := tmdbMatch{r, titles, 0}
+		m.confidence = matchConfidence(tr, m, tv, popularity)
+		matches = append(matches, m)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].confidence > matches[j].confidence
+	})
+
+	if matches[0].confidence < verifiedConfidence {
+		for n := 0; n < len(matches) && n < matchAlternatives; n++ {
+			alt, err := t.GetAlternativeTitles(strconv.Itoa(matches[n].result.Id), tv)
+			if err != nil {
+				log.Printf("ERROR: GetAlternativeTitles: %s\n", err.Error())
+				break
+			}
+
+			matches[n].titles = append(matches[n].titles, alt...)
+			matches[n].confidence = matchConfidence(tr, matches[n], tv, popularity)
+		}
+
+		sort.SliceStable(matches, func(i, j int) bool {
+			return matches[i].confidence > matches[j].confidence
+		})
+	}
+
+	if verbose && matches[0].confidence < verifiedConfidence {
+		log.Printf("TMDB: %s matched %d with confidence %.2f\n", tr.FormattedTitle, matches[0].result.Id, matches[0].confidence)
+	}
+
+	return matches[0].result, matches[0].confidence
+}
+
+// matchConfidence returns 0-1 confidence that candidate is the title of torrent
+func matchConfidence(tr TTorrent, m tmdbMatch, tv bool, popularity float64) float64 {
+	var title float64
+	for _, t := range m.titles {
+		if s := titleSimilarity(tr.FormattedTitle, t); s > title {
+			title = s
+		}
+	}
+
+	date := m.result.Release_date
+	if tv {
+		date = m.result.First_air_date
+	}
+
+	pop := 0.5
+	if popularity > 0 {
+		pop = m.result.Popularity / popularity
+	}
+
+	media := 1.0
+	if m.result.Media_type != "" && (m.result.Media_type == "tv") != tv {
+		media = 0
+	}
+
+	return round(matchTitle*title + matchYear*yearSimilarity(tr.Year, getYear(date)) + matchPopularity*pop + matchType*media)
+}
+
+// titleSimilarity returns 0-1 similarity of normalized titles, as dice coefficient of their words
+func titleSimilarity(a, b string) float64 {
+	wa := strings.Fields(normalizeTitle(a))
+	wb := strings.Fields(normalizeTitle(b))
+	if len(wa) == 0 || len(wb) == 0 {
+		return 0
+	}
+
+	if strings.Join(wa, " ") == strings.Join(wb, " ") {
+		return 1
+	}
+
+	words := make(map[string]int)
+	for _, w := range wa {
+		words[w]++
+	}
+
+	common := 0
+	for _, w := range wb {
+		if words[w] > 0 {
+			words[w]--
+			common++
+		}
+	}
+
+	return float64(2*common) / float64(len(wa)+len(wb))
+}
+
+// yearSimilarity returns 0-1 similarity of years, unknown year is half similar
+func yearSimilarity(a, b string) float64 {
+	ya, err1 := strconv.Atoi(a)
+	yb, err2 := strconv.Atoi(b)
+	if err1 != nil || err2 != nil {
+		return 0.5
+	}
+
+	switch d := ya - yb; {
+	case d == 0:
+		return 1
+	case d == 1 || d == -1:
+		return 0.7
+	case d == 2 || d == -2:
+		return 0.2
+	}
+
+	return 0
+}
+
+// normalizeTitle returns lower case title with punctuation, leading article and "and" sign normalized
+func normalizeTitle(title string) string {
+	title = strings.ToLower(title)
+	title = strings.Replace(title, "&", " and ", -1)
+
+	title = strings.Map(func(r rune) rune {
+		if r == '\'' {
+			return -1
+		}
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127 {
+			return r
+		}
+		return ' '
+	}, title)
+
+	title = strings.Join(strings.Fields(title), " ")
+	title = strings.TrimPrefix(title, "the ")
+
+	return title
+}
+
+// englishTitle returns title of movie or tv show used to search torrents, torrents are named in english.
+// Localized titles are replaced with english title, original title is used when it is not available.
+func (t *tmdb) englishTitle(r tmdbResult, tv bool) string {
+	title, original := r.Title, r.Original_title
+	if tv {
+		title, original = r.Name, r.Original_name
+	}
+
+	if !t.localized() {
+		return title
+	}
+
+	if r.Original_language == "en" && original != "" {
+		return original
+	}
+
+	var res tmdbMovie
+	var err error
+	if tv {
+		res, err = t.english().GetTvDetails(strconv.Itoa(r.Id), -1)
+		res.Title = res.Name
+	} else {
+		res, err = t.english().GetMovieDetails(strconv.Itoa(r.Id))
+	}
+	if err != nil {
+		log.Printf("ERROR: English title %d: %s\n", r.Id, err.Error())
+	} else if res.Title != "" {
+		return res.Title
+	}
+
+	if original != "" {
+		return original
+	}
+
+	return title
+}
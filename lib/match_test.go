@@ -0,0 +1,77 @@
+package bukanir
+
+import (
+	"testing"
+)
+
+func TestTitleSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"the lord of the rings", "The Lord of the Rings", 1},
+		{"fast and furious", "Fast & Furious", 1},
+		{"amelie", "Amélie", 0},
+		{"spider man", "Spider-Man", 1},
+		{"dont look up", "Don't Look Up", 1},
+		{"alien", "Aliens", 0},
+	}
+
+	for _, tt := range tests {
+		if got := titleSimilarity(tt.a, tt.b); got != tt.want {
+			t.Errorf("titleSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestMatchConfidence(t *testing.T) {
+	tr := TTorrent{FormattedTitle: "dune", Year: "2021"}
+
+	results := []tmdbResult{
+		{Id: 1, Title: "Dune", Release_date: "1984-12-14", Popularity: 40},
+		{Id: 2, Title: "Dune", Release_date: "2021-09-15", Popularity: 80},
+		{Id: 3, Title: "Dune Drifter", Release_date: "2020-11-01", Popularity: 5},
+	}
+
+	best, confidence := 0, 0.0
+	for _, r := range results {
+		c := matchConfidence(tr, tmdbMatch{r, []string{r.Title, r.Original_title}, 0}, false, 80)
+		if c > confidence {
+			best, confidence = r.Id, c
+		}
+	}
+
+	if best != 2 {
+		t.Errorf("best match %d, want 2", best)
+	}
+
+	if confidence < verifiedConfidence {
+		t.Errorf("confidence %v of exact match is not verified", confidence)
+	}
+
+	drifter := matchConfidence(tr, tmdbMatch{results[2], []string{results[2].Title}, 0}, false, 80)
+	if drifter >= verifiedConfidence {
+		t.Errorf("confidence %v of partial match is verified", drifter)
+	}
+
+	tv := matchConfidence(tr, tmdbMatch{tmdbResult{Title: "Dune", Release_date: "2021-09-15", Media_type: "tv"}, []string{"Dune"}, 0}, false, 80)
+	if tv >= confidence {
+		t.Errorf("confidence %v of tv result is not lower than %v", tv, confidence)
+	}
+}
+
+func TestEnglishTitle(t *testing.T) {
+	r := tmdbResult{Title: "Der Pate", Original_title: "The Godfather", Original_language: "en", Name: "Haus des Geldes", Original_name: "La casa de papel"}
+
+	if title := (&tmdb{}).englishTitle(r, false); title != "Der Pate" {
+		t.Errorf("title %q, want Der Pate", title)
+	}
+
+	if title := (&tmdb{Language: "de"}).englishTitle(r, false); title != "The Godfather" {
+		t.Errorf("title %q, want The Godfather", title)
+	}
+
+	if title := (&tmdb{}).englishTitle(r, true); title != "Haus des Geldes" {
+		t.Errorf("title %q, want Haus des Geldes", title)
+	}
+}
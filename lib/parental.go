@@ -0,0 +1,337 @@
+package bukanir
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Parental controls, PIN is kept as salted hash and override disables controls until it expires
+var (
+	parentalMu       sync.RWMutex
+	parentalControls TParental
+	parentalSalt     []byte
+	parentalPin      []byte
+	parentalOverride time.Time
+)
+
+// SetParentalControls sets parental controls from json string, PIN is required when it is set.
+// First non-empty pin also sets the PIN.
+func SetParentalControls(js string, pin string) error {
+	var p TParental
+	err := json.Unmarshal([]byte(js), &p)
+	if err != nil {
+		return err
+	}
+
+	parentalMu.Lock()
+	defer parentalMu.Unlock()
+
+	if parentalPin != nil && !checkPin(pin) {
+		return ErrInvalidPin
+	}
+
+	if parentalPin == nil && pin != "" {
+		err = setPin(pin)
+		if err != nil {
+			return err
+		}
+	}
+
+	p.Country = strings.ToUpper(p.Country)
+	parentalControls = p
+	return nil
+}
+
+// SetParentalPin changes parental controls PIN, empty newPin removes the PIN
+func SetParentalPin(pin string, newPin string) error {
+	parentalMu.Lock()
+	defer parentalMu.Unlock()
+
+	if parentalPin != nil && !checkPin(pin) {
+		return ErrInvalidPin
+	}
+
+	if newPin == "" {
+		parentalSalt, parentalPin = nil, nil
+		return nil
+	}
+
+	return setPin(newPin)
+}
+
+// ParentalOverride disables parental controls for minutes
+func ParentalOverride(pin string, minutes int) error {
+	parentalMu.Lock()
+	defer parentalMu.Unlock()
+
+	if parentalPin == nil || !checkPin(pin) {
+		return ErrInvalidPin
+	}
+
+	parentalOverride = time.Now().Add(time.Duration(minutes) * time.Minute)
+	return nil
+}
+
+// ParentalLock ends parental controls override
+func ParentalLock() {
+	parentalMu.Lock()
+	defer parentalMu.Unlock()
+
+	parentalOverride = time.Time{}
+}
+
+// ParentalControls returns parental controls
+func ParentalControls() TParental {
+	parentalMu.RLock()
+	defer parentalMu.RUnlock()
+
+	return parentalControls
+}
+
+// setPin sets PIN hash with new salt, parentalMu must be held
+func setPin(pin string) error {
+	salt := make([]byte, 16)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return err
+	}
+
+	parentalSalt = salt
+	parentalPin = hashPin(parentalSalt, pin)
+	return nil
+}
+
+// checkPin checks PIN against its hash, parentalMu must be held
+func checkPin(pin string) bool {
+	return subtle.ConstantTimeCompare(hashPin(parentalSalt, pin), parentalPin) == 1
+}
+
+// hashPin returns salted PIN hash
+func hashPin(salt []byte, pin string) []byte {
+	h := sha256.Sum256(append(append([]byte{}, salt...), pin...))
+	return h[:]
+}
+
+// getParental returns active parental controls, false when controls are off or overridden
+func getParental() (TParental, bool) {
+	parentalMu.RLock()
+	defer parentalMu.RUnlock()
+
+	p := parentalControls
+	if time.Now().Before(parentalOverride) {
+		return p, false
+	}
+
+	return p, p.MaxMovieRating != "" || p.MaxTvRating != "" || p.HideAdult || p.HideUnrated
+}
+
+// parentalKey returns cache key of active parental controls, empty when controls are not active
+func parentalKey() string {
+	p, ok := getParental()
+	if !ok {
+		return ""
+	}
+
+	js, _ := json.Marshal(p)
+	return string(js)
+}
+
+// Certifications returns movie or tv certifications of country, ordered from least to most restrictive
+func (c *Client) Certifications(ctx context.Context, country string, tv bool) ([]TCertification, error) {
+	certs := make([]TCertification, 0)
+	md := NewTmdb(ctx)
+
+	res, err := md.Certifications(tv)
+	if err != nil {
+		log.Printf("ERROR: Certifications: %v\n", err.Error())
+		return certs, metadataError(err)
+	}
+
+	for _, cr := range res[strings.ToUpper(country)] {
+		certs = append(certs, TCertification{cr.Certification, cr.Meaning, cr.Order})
+	}
+
+	sort.SliceStable(certs, func(i, j int) bool {
+		return certs[i].Order < certs[j].Order
+	})
+
+	if len(certs) == 0 {
+		return certs, ErrNoResults
+	}
+
+	return certs, nil
+}
+
+// allowed checks if movie or tv show passes active parental controls, titles that can not be checked are not allowed
+func (c *call) allowed(id int, tv bool) bool {
+	p, ok := getParental()
+	if !ok {
+		return true
+	}
+
+	md := NewTmdb(c.ctx)
+
+	var res tmdbMovie
+	var err error
+	if tv {
+		res, err = md.GetTvDetails(strconv.Itoa(id), -1)
+	} else {
+		res, err = md.GetMovieDetails(strconv.Itoa(id))
+	}
+	if err != nil {
+		log.Printf("ERROR: Parental details %d: %s\n", id, err.Error())
+		return false
+	}
+
+	if p.HideAdult && res.Adult {
+		return false
+	}
+
+	cert := certification(res, p.Country, tv)
+	if cert == "" {
+		return !p.HideUnrated
+	}
+
+	maxRating := p.MaxMovieRating
+	if tv {
+		maxRating = p.MaxTvRating
+	}
+
+	if maxRating == "" {
+		return true
+	}
+
+	certs, err := md.Certifications(tv)
+	if err != nil {
+		log.Printf("ERROR: Certifications: %s\n", err.Error())
+		return false
+	}
+
+	return certificationAllowed(certs[p.Country], cert, maxRating, p.HideUnrated)
+}
+
+// certification returns certification of movie or tv show in country, theatrical release is preferred for movies
+func certification(res tmdbMovie, country string, tv bool) string {
+	if tv {
+		for _, r := range res.Content_ratings.Results {
+			if r.Iso_3166_1 == country {
+				return r.Rating
+			}
+		}
+		return ""
+	}
+
+	cert := ""
+	for _, r := range res.Release_dates.Results {
+		if r.Iso_3166_1 != country {
+			continue
+		}
+
+		for _, d := range r.Release_dates {
+			if d.Certification == "" {
+				continue
+			}
+			if d.Type == 3 {
+				return d.Certification
+			}
+			if cert == "" {
+				cert = d.Certification
+			}
+		}
+	}
+
+	return cert
+}
+
+// certificationAllowed checks if certification is not more restrictive than maxRating, unknown certifications are unrated.
+// Unknown maxRating allows nothing.
+func certificationAllowed(certs []tmdbCertification, cert string, maxRating string, hideUnrated bool) bool {
+	order, maxOrder := -1, -1
+	for _, c := range certs {
+		if c.Certification == cert {
+			order = c.Order
+		}
+		if c.Certification == maxRating {
+			maxOrder = c.Order
+		}
+	}
+
+	if maxOrder == -1 {
+		return false
+	}
+
+	if order == -1 {
+		return !hideUnrated
+	}
+
+	return order <= maxOrder
+}
+
+// parental returns movies that pass active parental controls
+func (c *call) parental(movs []TMovie) []TMovie {
+	if _, ok := getParental(); !ok {
+		return movs
+	}
+
+	type title struct {
+		id int
+		tv bool
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	th := make(chan int, runtime.NumCPU())
+	allowed := make(map[title]bool)
+
+	for _, m := range movs {
+		t := title{m.Id, m.Category == CategoryTV || m.Category == CategoryHDTV}
+
+		mu.Lock()
+		_, ok := allowed[t]
+		allowed[t] = false
+		mu.Unlock()
+
+		if ok {
+			continue
+		}
+
+		th <- 1
+		wg.Add(1)
+		go func(t title) {
+			defer func() {
+				<-th
+				wg.Done()
+			}()
+
+			a := c.allowed(t.id, t.tv)
+
+			mu.Lock()
+			allowed[t] = a
+			mu.Unlock()
+		}(t)
+	}
+	wg.Wait()
+
+	results := make([]TMovie, 0)
+	for _, m := range movs {
+		if allowed[title{m.Id, m.Category == CategoryTV || m.Category == CategoryHDTV}] {
+			results = append(results, m)
+		}
+	}
+
+	if verbose && len(results) < len(movs) {
+		log.Printf("BUK: Parental controls hid %d movies\n", len(movs)-len(results))
+	}
+
+	return results
+}
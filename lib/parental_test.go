@@ -0,0 +1,94 @@
+package bukanir
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParentalPin(t *testing.T) {
+	defer func() {
+		SetParentalPin("1234", "")
+		SetParentalControls("{}", "")
+	}()
+
+	err := SetParentalControls(`{"country": "us", "maxMovieRating": "PG-13"}`, "1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := getParental(); !ok {
+		t.Error("parental controls are not active")
+	}
+
+	if ParentalControls().Country != "US" {
+		t.Errorf("country %q, want US", ParentalControls().Country)
+	}
+
+	err = SetParentalControls(`{}`, "0000")
+	if !errors.Is(err, ErrInvalidPin) {
+		t.Errorf("SetParentalControls with wrong pin: %v", err)
+	}
+
+	if err := ParentalOverride("0000", 10); !errors.Is(err, ErrInvalidPin) {
+		t.Errorf("ParentalOverride with wrong pin: %v", err)
+	}
+
+	if err := ParentalOverride("1234", 10); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := getParental(); ok {
+		t.Error("parental controls are active during override")
+	}
+
+	if parentalKey() != "" {
+		t.Error("cache key is not empty during override")
+	}
+
+	ParentalLock()
+
+	if _, ok := getParental(); !ok {
+		t.Error("parental controls are not active after lock")
+	}
+}
+
+func TestCertification(t *testing.T) {
+	var res tmdbMovie
+	err := json.Unmarshal([]byte(`{"release_dates": {"results": [
+		{"iso_3166_1": "DE", "release_dates": [{"certification": "12", "type": 3}]},
+		{"iso_3166_1": "US", "release_dates": [{"certification": "", "type": 1}, {"certification": "R", "type": 4}, {"certification": "PG-13", "type": 3}]}
+	]}}`), &res)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c := certification(res, "US", false); c != "PG-13" {
+		t.Errorf("certification %q, want theatrical PG-13", c)
+	}
+
+	if c := certification(res, "GB", false); c != "" {
+		t.Errorf("certification %q, want unrated", c)
+	}
+
+	certs := []tmdbCertification{{"G", "", 1}, {"PG", "", 2}, {"PG-13", "", 3}, {"R", "", 4}, {"NC-17", "", 5}}
+
+	tests := []struct {
+		cert, max string
+		unrated   bool
+		want      bool
+	}{
+		{"PG", "PG-13", false, true},
+		{"PG-13", "PG-13", false, true},
+		{"R", "PG-13", false, false},
+		{"X", "PG-13", false, true},
+		{"X", "PG-13", true, false},
+		{"R", "XXX", false, false},
+	}
+
+	for _, tt := range tests {
+		if got := certificationAllowed(certs, tt.cert, tt.max, tt.unrated); got != tt.want {
+			t.Errorf("certificationAllowed(%q, %q, %v) = %v, want %v", tt.cert, tt.max, tt.unrated, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package bukanir
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Number of titles a person is known for
+const knownForLimit = 5
+
+// SearchPerson returns people matching query, sorted by popularity
+func (c *Client) SearchPerson(ctx context.Context, query string, limit int) ([]TPerson, error) {
+	people := make([]TPerson, 0)
+	md := NewTmdb(ctx)
+
+	config, err := md.GetConfig()
+	if err != nil {
+		log.Printf("ERROR: TMDB GetConfig: %s\n", err.Error())
+		return people, metadataError(err)
+	}
+
+	res, err := md.SearchPerson(query)
+	if err != nil {
+		log.Printf("ERROR: SearchPerson: %v\n", err.Error())
+		return people, metadataError(err)
+	}
+
+	for _, p := range res.Results {
+		if limit > 0 && len(people) >= limit {
+			break
+		}
+
+		known := make([]string, 0)
+		for _, r := range p.Known_for {
+			title := r.Title
+			if r.Media_type == "tv" {
+				title = r.Name
+			}
+			known = append(known, title)
+		}
+
+		person := TPerson{
+			p.Id,
+			p.Name,
+			p.Known_for_department,
+			imageUrl(config, config.Images.Profile_sizes, 1, p.Profile_path),
+			known,
+		}
+		people = append(people, person)
+	}
+
+	if len(people) == 0 {
+		return people, ErrNoResults
+	}
+
+	return people, nil
+}
+
+// Person returns person details with combined movie and tv credits, newest first
+func (c *Client) Person(ctx context.Context, id int) (TPersonDetails, error) {
+	var details TPersonDetails
+	md := NewTmdb(ctx)
+
+	config, err := md.GetConfig()
+	if err != nil {
+		log.Printf("ERROR: TMDB GetConfig: %s\n", err.Error())
+		return details, metadataError(err)
+	}
+
+	p, err := md.GetPersonDetails(strconv.Itoa(id))
+	if err != nil {
+		log.Printf("ERROR: GetPersonDetails: %v\n", err.Error())
+		return details, metadataError(err)
+	}
+
+	details = TPersonDetails{
+		p.Id,
+		p.Name,
+		p.Known_for_department,
+		p.Biography,
+		p.Birthday,
+		p.Deathday,
+		p.Place_of_birth,
+		imageUrl(config, config.Images.Profile_sizes, 2, p.Profile_path),
+		knownFor(append(p.Combined_credits.Cast, p.Combined_credits.Crew...)),
+		personCredits(config, p.Combined_credits.Cast),
+		personCredits(config, p.Combined_credits.Crew),
+		md.stale,
+	}
+
+	return details, nil
+}
+
+// personCredits returns credits, jobs on the same title are joined
+func personCredits(config *tmdbConfig, credits []tmdbCredit) []TCredit {
+	ret := make([]TCredit, 0)
+	index := make(map[string]int)
+
+	for _, cr := range credits {
+		key := cr.Media_type + strconv.Itoa(cr.Id)
+		if n, ok := index[key]; ok {
+			if cr.Job != "" && !strings.Contains(ret[n].Job, cr.Job) {
+				ret[n].Job += ", " + cr.Job
+			}
+			continue
+		}
+
+		title, date, category := cr.Title, cr.Release_date, CategoryMovies
+		if cr.Media_type == "tv" {
+			title, date, category = cr.Name, cr.First_air_date, CategoryTV
+		}
+
+		credit := TCredit{
+			cr.Id,
+			title,
+			getYear(date),
+			category,
+			cr.Character,
+			cr.Job,
+			imageUrl(config, config.Images.Poster_sizes, 1, cr.Poster_path),
+		}
+
+		index[key] = len(ret)
+		ret = append(ret, credit)
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Year > ret[j].Year
+	})
+
+	return ret
+}
+
+// knownFor returns titles of most popular credits
+func knownFor(credits []tmdbCredit) []string {
+	sorted := make([]tmdbCredit, len(credits))
+	copy(sorted, credits)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Popularity > sorted[j].Popularity
+	})
+
+	titles := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, cr := range sorted {
+		if len(titles) >= knownForLimit {
+			break
+		}
+
+		title := cr.Title
+		if cr.Media_type == "tv" {
+			title = cr.Name
+		}
+
+		if title != "" && !seen[title] {
+			seen[title] = true
+			titles = append(titles, title)
+		}
+	}
+
+	return titles
+}
@@ -0,0 +1,38 @@
+package bukanir
+
+import (
+	"testing"
+)
+
+func TestPersonCredits(t *testing.T) {
+	config := &tmdbConfig{tmdbImageConfig{Base_url: "http://image/", Poster_sizes: []string{"w92", "w154"}}}
+
+	credits := []tmdbCredit{
+		{Id: 1, Title: "Arrival", Release_date: "2016-09-01", Media_type: "movie", Job: "Director", Popularity: 30},
+		{Id: 2, Name: "Dune: Prophecy", First_air_date: "2024-11-17", Media_type: "tv", Job: "Executive Producer", Popularity: 60},
+		{Id: 3, Title: "Dune", Release_date: "2021-09-15", Media_type: "movie", Job: "Director", Popularity: 90, Poster_path: "/dune.jpg"},
+		{Id: 3, Title: "Dune", Release_date: "2021-09-15", Media_type: "movie", Job: "Screenplay", Popularity: 90},
+	}
+
+	ret := personCredits(config, credits)
+	if len(ret) != 3 {
+		t.Fatalf("got %d credits, want 3", len(ret))
+	}
+
+	if ret[0].Title != "Dune: Prophecy" || ret[0].Category != CategoryTV {
+		t.Errorf("first credit %+v, want newest tv show", ret[0])
+	}
+
+	if ret[1].Job != "Director, Screenplay" {
+		t.Errorf("jobs %q, want joined jobs", ret[1].Job)
+	}
+
+	if ret[1].Poster != "http://image/w154/dune.jpg" {
+		t.Errorf("poster %q", ret[1].Poster)
+	}
+
+	known := knownFor(credits)
+	if len(known) != 3 || known[0] != "Dune" || known[1] != "Dune: Prophecy" {
+		t.Errorf("known for %v", known)
+	}
+}
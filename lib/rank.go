@@ -0,0 +1,235 @@
+package bukanir
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default weights, used when no weights are given
+var defaultWeights = TWeights{
+	Seeders:    3,
+	Resolution: 2,
+	Source:     1.5,
+	Size:       1,
+	Confidence: 2,
+	Provider:   0.5,
+	Age:        0.5,
+	ProviderTrust: map[string]float64{
+		"tpb":  0.6,
+		"eztv": 0.8,
+	},
+}
+
+// Expected size in MiB per minute of video, by resolution
+var sizePerMinute = map[int]float64{
+	480:  6,
+	576:  8,
+	720:  12,
+	1080: 25,
+	2160: 80,
+}
+
+// Typical runtime in minutes, used because runtime is not known before summary
+const (
+	runtimeMovie   = 110
+	runtimeEpisode = 45
+	runtimeSeason  = 450
+)
+
+// DefaultWeights returns default score weights
+func DefaultWeights() TWeights {
+	return defaultWeights
+}
+
+// getWeights returns weights from json string, empty string returns default weights and missing weights are default
+func getWeights(js string) (TWeights, error) {
+	if strings.TrimSpace(js) == "" {
+		return defaultWeights, nil
+	}
+
+	// Provider trust is copied, json is decoded into the map
+	w := defaultWeights
+	w.ProviderTrust = make(map[string]float64)
+	for k, v := range defaultWeights.ProviderTrust {
+		w.ProviderTrust[k] = v
+	}
+
+	err := json.Unmarshal([]byte(js), &w)
+	if err != nil {
+		return defaultWeights, err
+	}
+
+	if w.ProviderTrust == nil {
+		w.ProviderTrust = defaultWeights.ProviderTrust
+	}
+
+	return w, nil
+}
+
+// rankMovies sets score and score breakdown for each movie
+func rankMovies(movs []TMovie, w TWeights, p TProfile) {
+	now := time.Now()
+	for n := range movs {
+		movs[n].ScoreBreakdown = w.breakdown(movs[n], p, now)
+		movs[n].Score = movs[n].ScoreBreakdown.total()
+	}
+}
+
+// breakdown returns weighted score components for movie
+func (w TWeights) breakdown(m TMovie, p TProfile, now time.Time) TScore {
+	return TScore{
+		round(w.Seeders * healthScore(m.Seeders, m.Leechers)),
+		round(w.Resolution * resolutionScore(m.ReleaseInfo.Resolution, p.Resolution)),
+		round(w.Source * sourceScore(m.ReleaseInfo.Source, p.Sources)),
+		round(w.Size * sizeScore(m)),
+		round(w.Confidence * confidenceScore(m)),
+		round(w.Provider * w.trust(m.Provider)),
+		round(w.Age * ageScore(m.Added, now)),
+	}
+}
+
+// total returns sum of score components
+func (s TScore) total() float64 {
+	return round(s.Seeders + s.Resolution + s.Source + s.Size + s.Confidence + s.Provider + s.Age)
+}
+
+// trust returns provider trust, unknown providers are half trusted
+func (w TWeights) trust(provider string) float64 {
+	if t, ok := w.ProviderTrust[provider]; ok {
+		return t
+	}
+
+	return 0.5
+}
+
+// healthScore returns 0-1 score from seeders and leechers, 1000 seeders is full score
+func healthScore(seeders, leechers int) float64 {
+	if seeders <= 0 {
+		return 0
+	}
+
+	score := math.Min(1, math.Log10(float64(seeders+1))/3)
+	if leechers > 0 {
+		ratio := float64(seeders) / float64(seeders+leechers)
+		score = 0.8*score + 0.2*ratio
+	}
+
+	return score
+}
+
+// resolutionScore returns 0-1 score for how close resolution is to preferred one
+func resolutionScore(resolution string, preferred int) float64 {
+	// Releases without resolution are mostly SD
+	res := 480
+	if resolution != "" {
+		res, _ = strconv.Atoi(strings.TrimSuffix(resolution, "p"))
+	}
+
+	if preferred == 0 {
+		return math.Min(1, float64(res)/2160)
+	}
+
+	if res == preferred {
+		return 1
+	} else if res > preferred {
+		return 0.6
+	}
+
+	return 0.5 * float64(res) / float64(preferred)
+}
+
+// sourceScore returns 0-1 score by source position in preferred list
+func sourceScore(source string, preferred []string) float64 {
+	if source == "" || len(preferred) == 0 {
+		return 0.5
+	}
+
+	for n, p := range preferred {
+		if strings.EqualFold(source, p) {
+			return 1 - float64(n)/float64(len(preferred))
+		}
+	}
+
+	return 0
+}
+
+// sizeScore returns 0-1 score for how plausible size is for typical runtime and resolution
+func sizeScore(m TMovie) float64 {
+	if m.Size <= 0 {
+		return 0
+	}
+
+	res := 480
+	if m.ReleaseInfo.Resolution != "" {
+		res, _ = strconv.Atoi(strings.TrimSuffix(m.ReleaseInfo.Resolution, "p"))
+	}
+
+	perMinute, ok := sizePerMinute[res]
+	if !ok {
+		perMinute = sizePerMinute[480]
+	}
+
+	minutes := float64(runtimeMovie)
+	if m.ReleaseInfo.SeasonPack {
+		minutes = runtimeSeason
+	} else if m.Category == CategoryTV || m.Category == CategoryHDTV {
+		minutes = runtimeEpisode
+	}
+
+	expected := perMinute * minutes * 1024 * 1024
+	ratio := math.Abs(math.Log(float64(m.Size) / expected))
+
+	return 1 / (1 + ratio)
+}
+
+// confidenceScore returns 0-1 score for how likely the release matches the TMDB title, match confidence is used when known
+func confidenceScore(m TMovie) float64 {
+	if m.Confidence > 0 {
+		return m.Confidence
+	}
+
+	score := 0.5
+	if getTitle(m.Release) == getTitle(m.Title) {
+		score = 1
+	}
+
+	if m.Category == CategoryTV || m.Category == CategoryHDTV {
+		return score
+	}
+
+	tmdbYear, err1 := strconv.Atoi(m.Year)
+	torrentYear, err2 := strconv.Atoi(m.ReleaseInfo.Year)
+	if err1 != nil || err2 != nil {
+		return score * 0.8
+	}
+
+	if tmdbYear == torrentYear {
+		return score
+	} else if tmdbYear == torrentYear-1 || tmdbYear == torrentYear+1 {
+		return score * 0.7
+	}
+
+	return score * 0.3
+}
+
+// ageScore returns 0-1 score for release age, newer is better, unknown age is half score
+func ageScore(added int64, now time.Time) float64 {
+	if added <= 0 {
+		return 0.5
+	}
+
+	days := now.Sub(time.Unix(added, 0)).Hours() / 24
+	if days < 0 {
+		days = 0
+	}
+
+	return 1 / (1 + days/180)
+}
+
+// round rounds to two decimals
+func round(f float64) float64 {
+	return math.Round(f*100) / 100
+}
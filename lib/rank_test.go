@@ -0,0 +1,101 @@
+package bukanir
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func rankMovie(title, year, release string, size int64, seeders, category int, provider string) TMovie {
+	return TMovie{
+		Title:       title,
+		Year:        year,
+		Release:     release,
+		Size:        size,
+		Seeders:     seeders,
+		Category:    category,
+		Provider:    provider,
+		ReleaseInfo: parseRelease(release),
+	}
+}
+
+func TestRankMovies(t *testing.T) {
+	movs := []TMovie{
+		rankMovie("Barbie", "2023", "Barbie.2023.HDCAM.x264-SUNSCREEN", 1<<30, 900, CategoryMovies, "tpb"),
+		rankMovie("Barbie", "2023", "Barbie.2023.1080p.BluRay.x264-FLUX", 3<<30, 300, CategoryHDmovies, "tpb"),
+		rankMovie("Barbie", "2023", "Barbie.2023.1080p.BluRay.x264-FAKE", 300<<20, 300, CategoryHDmovies, "tpb"),
+		rankMovie("Barbie", "2023", "Barbie.1959.1080p.BluRay.x264-OLD", 3<<30, 300, CategoryHDmovies, "tpb"),
+	}
+
+	rankMovies(movs, defaultWeights, defaultProfile)
+
+	for _, m := range movs {
+		if m.Score != m.ScoreBreakdown.total() {
+			t.Errorf("%s: score %v does not match breakdown %+v", m.Release, m.Score, m.ScoreBreakdown)
+		}
+	}
+
+	sort.Sort(ByRank(movs))
+
+	want := []string{
+		"Barbie.2023.1080p.BluRay.x264-FLUX",
+		"Barbie.2023.1080p.BluRay.x264-FAKE",
+		"Barbie.1959.1080p.BluRay.x264-OLD",
+		"Barbie.2023.HDCAM.x264-SUNSCREEN",
+	}
+
+	for n, m := range movs {
+		if m.Release != want[n] {
+			t.Errorf("%d: got %s (%v), want %s", n, m.Release, m.Score, want[n])
+		}
+	}
+
+	w := TWeights{Seeders: 1}
+	rankMovies(movs, w, defaultProfile)
+	sort.Sort(ByRank(movs))
+
+	if movs[0].Release != "Barbie.2023.HDCAM.x264-SUNSCREEN" {
+		t.Errorf("got %s, want most seeded release with seeders only weights", movs[0].Release)
+	}
+}
+
+func TestScores(t *testing.T) {
+	if healthScore(0, 10) != 0 || healthScore(999, 0) != 1 {
+		t.Error("unexpected health score")
+	}
+
+	if resolutionScore("1080p", 1080) != 1 || resolutionScore("2160p", 1080) >= 1 || resolutionScore("", 1080) >= resolutionScore("720p", 1080) {
+		t.Error("unexpected resolution score")
+	}
+
+	if sourceScore("BluRay", defaultProfile.Sources) != 1 || sourceScore("CAM", defaultProfile.Sources) != 0 || sourceScore("", defaultProfile.Sources) != 0.5 {
+		t.Error("unexpected source score")
+	}
+
+	now := time.Unix(time.Now().Unix(), 0)
+	if ageScore(now.Unix(), now) != 1 || ageScore(0, now) != 0.5 || ageScore(now.AddDate(-1, 0, 0).Unix(), now) >= 0.5 {
+		t.Error("unexpected age score")
+	}
+
+	w, err := getWeights(`{"seeders": 1}`)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if w.Seeders != 1 || w.Resolution != defaultWeights.Resolution || w.trust("eztv") != defaultWeights.ProviderTrust["eztv"] {
+		t.Errorf("unexpected weights %+v", w)
+	}
+
+	w, err = getWeights(`{"resolution": 0, "providerTrust": {"tpb": 1}}`)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if w.Resolution != 0 || w.Seeders != defaultWeights.Seeders || w.trust("tpb") != 1 || w.trust("eztv") != defaultWeights.ProviderTrust["eztv"] {
+		t.Errorf("unexpected weights %+v", w)
+	}
+
+	if defaultWeights.ProviderTrust["tpb"] == 1 {
+		t.Error("default provider trust changed")
+	}
+}
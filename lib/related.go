@@ -0,0 +1,211 @@
+package bukanir
+
+import (
+	"context"
+	"log"
+	"runtime"
+	"sort"
+	"strconv"
+)
+
+// Similar returns similar movies or tv shows that have a torrent
+func (c *Client) Similar(ctx context.Context, id int, category int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, f TFilter) ([]TMovie, error) {
+	return c.related(ctx, "similar", id, category, limit, force, cacheDir, cacheDays, tpbHost, f)
+}
+
+// Recommendations returns recommended movies or tv shows that have a torrent
+func (c *Client) Recommendations(ctx context.Context, id int, category int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, f TFilter) ([]TMovie, error) {
+	return c.related(ctx, "recommendations", id, category, limit, force, cacheDir, cacheDays, tpbHost, f)
+}
+
+// related returns similar or recommended titles that have a torrent
+func (c *Client) related(ctx context.Context, kind string, id int, category int, limit int, force int, cacheDir string, cacheDays int64, tpbHost string, f TFilter) ([]TMovie, error) {
+	tv := category == CategoryTV || category == CategoryHDTV
+
+	key := kind + strconv.Itoa(id) + strconv.FormatBool(tv) + f.key() + parentalKey() + localeKey()
+	if force != 1 {
+		movs := getMoviesCache(key, cacheDir, cacheDays)
+		if movs != nil {
+			return movs, nil
+		}
+	}
+
+	cl := newCall(ctx)
+
+	if tpbHost == "" {
+		tpbHost = getTpbHost()
+	} else {
+		if verbose {
+			log.Printf("TPB: Using host %s\n", tpbHost)
+		}
+	}
+
+	cl.wg.Add(1)
+	go cl.tmdbRelated(kind, id, tv, limit, tpbHost, f)
+	cl.wg.Wait()
+
+	return c.finish(cl, key, cacheDir, f)
+}
+
+// tmdbRelated TMDB similar or recommended titles, only titles with a torrent are kept
+func (c *call) tmdbRelated(kind string, id int, tv bool, limit int, tpbHost string, f TFilter) {
+	defer func() {
+		c.wg.Done()
+		if r := recover(); r != nil {
+			log.Print("TMDB: Recovered in tmdbRelated")
+		}
+	}()
+
+	md := NewTmdb(c.ctx)
+	config, err := md.GetConfig()
+	if err != nil {
+		log.Printf("ERROR: TMDB GetConfig: %s\n", err.Error())
+		c.fail(metadataError(err))
+		return
+	}
+
+	if len(config.Images.Poster_sizes) < 5 {
+		return
+	}
+
+	var m []tmdbResult
+	for n := 1; n < 3; n++ {
+		mp, err := md.Related(kind, id, tv, n)
+		if err != nil {
+			log.Printf("ERROR: Related %s: %v\n", kind, err.Error())
+			c.fail(metadataError(err))
+			return
+		}
+		m = append(m, mp.Results...)
+
+		if n >= mp.Total_pages {
+			break
+		}
+	}
+
+	if limit > 0 && limit < len(m) {
+		m = m[0:limit]
+	}
+
+	for n := range m {
+		if tv {
+			m[n].Media_type = "tv"
+		}
+	}
+
+	c.withTorrents(m, tpbHost, f, config)
+}
+
+// withTorrents adds movies or tv shows from TMDB results that have a torrent, tv shows are results with tv media type
+func (c *call) withTorrents(m []tmdbResult, tpbHost string, f TFilter, config *tmdbConfig) {
+	if len(m) == 0 {
+		return
+	}
+
+	md := NewTmdb(c.ctx)
+	pb := NewTpb(c.ctx, tpbHost)
+
+	var th = make(chan int, 3*runtime.NumCPU())
+
+	searchTorrents := func(r tmdbResult) {
+		defer c.wgt.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				log.Print("TMDB: Recovered in searchTorrents")
+			}
+		}()
+
+		defer func() {
+			<-th
+		}()
+
+		if r.Id == 0 {
+			return
+		}
+
+		tv := r.Media_type == "tv"
+
+		title, original, date, cats := r.Title, r.Original_title, r.Release_date, "201,207"
+		if tv {
+			title, original, date, cats = r.Name, r.Original_name, r.First_air_date, "205,208"
+		}
+
+		query := md.englishTitle(r, tv)
+
+		results, err := pb.Search(query, 0, cats)
+		if err != nil {
+			return
+		}
+
+		results = filterTorrents(results, f)
+
+		if len(results) == 0 {
+			return
+		}
+
+		sort.Sort(ByTSeeders(results))
+
+		var t TTorrent
+		for _, rt := range results {
+			if getTitle(query) != rt.FormattedTitle && getTitle(title) != rt.FormattedTitle && getTitle(original) != rt.FormattedTitle {
+				continue
+			}
+
+			if tv || yearSimilarity(getYear(date), rt.Year) >= 0.7 {
+				t = rt
+				break
+			}
+		}
+
+		if t.Title == "" {
+			return
+		}
+
+		movie := TMovie{
+			r.Id,
+			title,
+			getYear(date),
+			config.Images.Base_url + config.Images.Poster_sizes[0] + r.Poster_path,
+			config.Images.Base_url + config.Images.Poster_sizes[1] + r.Poster_path,
+			config.Images.Base_url + config.Images.Poster_sizes[3] + r.Poster_path,
+			config.Images.Base_url + config.Images.Poster_sizes[4] + r.Poster_path,
+			imageUrl(config, config.Images.Backdrop_sizes, 0, r.Backdrop_path),
+			imageUrl(config, config.Images.Backdrop_sizes, 1, r.Backdrop_path),
+			imageUrl(config, config.Images.Backdrop_sizes, 2, r.Backdrop_path),
+			int64(t.Size),
+			t.SizeHuman,
+			t.Seeders,
+			t.MagnetLink,
+			t.Title,
+			t.Category,
+			t.Season,
+			t.Episode,
+			getQuality(t.Title),
+			parseRelease(t.Title),
+			t.Provider,
+			t.Leechers,
+			t.Added,
+			0,
+			TScore{},
+			1,
+			true,
+			"",
+			0,
+			0,
+		}
+		c.Lock()
+		c.movies = append(c.movies, movie)
+		c.Unlock()
+	}
+
+	for _, res := range m {
+		if c.ctx.Err() != nil {
+			break
+		}
+
+		th <- 1
+		c.wgt.Add(1)
+		go searchTorrents(res)
+	}
+	c.wgt.Wait()
+}
@@ -0,0 +1,331 @@
+package bukanir
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// releaseRule type
+type releaseRule struct {
+	re   *regexp.Regexp
+	name string
+}
+
+var (
+	reRelExtension = regexp.MustCompile(`(?i)\.(mkv|mp4|avi|m4v|wmv|mov)$`)
+	reRelTag       = regexp.MustCompile(`[\[\{]([^\[\]\{\}]+)[\]\}]\s*$`)
+	reRelGroup     = regexp.MustCompile(`-\s*([A-Za-z0-9]+)\s*$`)
+	reRelYear      = regexp.MustCompile(`\b(19\d{2}|20\d{2})\b`)
+	reRelSpaces    = regexp.MustCompile(`\s+`)
+
+	reRelEpisode    = regexp.MustCompile(`(?i)\bs(\d{1,2})[ .]?e(\d{1,3})((?:-?e\d{1,3}|-\d{1,3})*)\b`)
+	reRelEpisodeX   = regexp.MustCompile(`(?i)\b(\d{1,2})x(\d{2,3})\b`)
+	reRelSeasonPack = regexp.MustCompile(`(?i)\bs(\d{1,2})(?:[ .]?-[ .]?s?(\d{1,2}))?\b`)
+	reRelSeasonWord = regexp.MustCompile(`(?i)\bseasons?[ .]?(\d{1,2})(?:[ .]?(?:-|to)[ .]?(\d{1,2}))?\b`)
+	reRelComplete   = regexp.MustCompile(`(?i)\bcomplete[ .](?:series|collection)\b`)
+	reRelNumbers    = regexp.MustCompile(`\d{1,3}`)
+
+	reRelResolution = regexp.MustCompile(`(?i)\b(?:\d{3,4}x)?(480|576|720|1080|1440|2160|4320)[pi]?\b`)
+	reRelUHD        = regexp.MustCompile(`(?i)\b(?:4k|uhd)\b`)
+
+	reRelProper = regexp.MustCompile(`(?i)\bproper\b`)
+	reRelRepack = regexp.MustCompile(`(?i)\b(?:repack|rerip)\b`)
+
+	reRelHDR10Plus = regexp.MustCompile(`(?i)\bhdr10(?:\+|plus)`)
+	reRelHDR10     = regexp.MustCompile(`(?i)\bhdr10\b`)
+	reRelHDR       = regexp.MustCompile(`(?i)\bhdr\b`)
+	reRelDV        = regexp.MustCompile(`(?i)\b(?:dv|dovi|dolby[ .]?vision)\b`)
+)
+
+// Release sources, the earliest match in the release name wins
+var releaseSources = []releaseRule{
+	{regexp.MustCompile(`(?i)\b(?:hd-?cam|cam-?rip|cam)\b`), "CAM"},
+	{regexp.MustCompile(`(?i)\b(?:hd-?ts|telesync|ts|pdvd)\b`), "TS"},
+	{regexp.MustCompile(`(?i)\b(?:hd-?tc|telecine|tc)\b`), "TC"},
+	{regexp.MustCompile(`(?i)\b(?:dvd-?scr|screener|scr)\b`), "SCR"},
+	{regexp.MustCompile(`(?i)\bweb-?rip\b`), "WEBRip"},
+	{regexp.MustCompile(`(?i)\b(?:web-?dl|web)\b`), "WEB-DL"},
+	{regexp.MustCompile(`(?i)\b(?:blu-?ray|bd-?rip|br-?rip|bd-?remux|remux|bd25|bd50)\b`), "BluRay"},
+	{regexp.MustCompile(`(?i)\b(?:hdtv|pdtv|sdtv|dsr|dsrip|tvrip)\b`), "HDTV"},
+	{regexp.MustCompile(`(?i)\b(?:dvd-?rip|dvd5|dvd9|dvd-?r|dvd)\b`), "DVDRip"},
+	{regexp.MustCompile(`(?i)\bhd-?rip\b`), "HDRip"},
+}
+
+// Release video codecs
+var releaseCodecs = []releaseRule{
+	{regexp.MustCompile(`(?i)\b(?:[xh][ .]?265|hevc)\b`), "x265"},
+	{regexp.MustCompile(`(?i)\b(?:[xh][ .]?264|avc)\b`), "x264"},
+	{regexp.MustCompile(`(?i)\bav1\b`), "AV1"},
+	{regexp.MustCompile(`(?i)\bvp9\b`), "VP9"},
+	{regexp.MustCompile(`(?i)\b(?:xvid|divx)\b`), "XviD"},
+}
+
+// Release audio formats, matched spans are blanked so DDP is not also reported as DD
+var releaseAudio = []releaseRule{
+	{regexp.MustCompile(`(?i)\b(?:ddp|dd\+|eac3|e-ac-3)(?:[ .]?(\d)[ .](\d))?`), "DDP"},
+	{regexp.MustCompile(`(?i)\btrue-?hd(?:[ .]?(\d)[ .](\d))?\b`), "TrueHD"},
+	{regexp.MustCompile(`(?i)\bdts-?hd(?:[ .-]?ma)?(?:[ .]?(\d)[ .](\d))?\b`), "DTS-HD"},
+	{regexp.MustCompile(`(?i)\bdts(?:[ .]?(\d)[ .](\d))?\b`), "DTS"},
+	{regexp.MustCompile(`(?i)\b(?:dd|ac3)(?:[ .]?(\d)[ .](\d))?\b`), "DD"},
+	{regexp.MustCompile(`(?i)\baac(?:[ .]?(\d)[ .](\d))?\b`), "AAC"},
+	{regexp.MustCompile(`(?i)\batmos\b`), "Atmos"},
+	{regexp.MustCompile(`(?i)\bflac(?:[ .]?(\d)[ .](\d))?\b`), "FLAC"},
+	{regexp.MustCompile(`(?i)\bopus\b`), "Opus"},
+	{regexp.MustCompile(`(?i)\bmp3\b`), "MP3"},
+}
+
+// Release language tags
+var releaseLanguages = []releaseRule{
+	{regexp.MustCompile(`(?i)\bmulti\b`), "Multi"},
+	{regexp.MustCompile(`(?i)\bdual(?:[ .-]?audio)?\b`), "Dual"},
+	{regexp.MustCompile(`(?i)\b(?:eng|english)\b`), "English"},
+	{regexp.MustCompile(`(?i)\b(?:ita|italian)\b`), "Italian"},
+	{regexp.MustCompile(`(?i)\b(?:ger|german|deutsch)\b`), "German"},
+	{regexp.MustCompile(`(?i)\b(?:fre|french|truefrench|vff|vostfr)\b`), "French"},
+	{regexp.MustCompile(`(?i)\b(?:spa|spanish|castellano|latino)\b`), "Spanish"},
+	{regexp.MustCompile(`(?i)\b(?:por|portuguese|dublado)\b`), "Portuguese"},
+	{regexp.MustCompile(`(?i)\b(?:rus|russian)\b`), "Russian"},
+	{regexp.MustCompile(`(?i)\b(?:pol|polish)\b`), "Polish"},
+	{regexp.MustCompile(`(?i)\b(?:hin|hindi)\b`), "Hindi"},
+	{regexp.MustCompile(`(?i)\b(?:jap|japanese)\b`), "Japanese"},
+	{regexp.MustCompile(`(?i)\b(?:kor|korean)\b`), "Korean"},
+	{regexp.MustCompile(`(?i)\b(?:chi|chinese|mandarin)\b`), "Chinese"},
+	{regexp.MustCompile(`(?i)\b(?:tur|turkish)\b`), "Turkish"},
+	{regexp.MustCompile(`(?i)\bnordic\b`), "Nordic"},
+}
+
+// Names that follow a dash but are not release groups
+var releaseNotGroups = []string{"dl", "rip", "hd", "ray", "audio", "ac-3", "ma"}
+
+// parseRelease parses torrent release name
+func parseRelease(name string) TReleaseInfo {
+	var info TReleaseInfo
+
+	name = reRelExtension.ReplaceAllString(strings.TrimSpace(name), "")
+	name = strings.Replace(name, "_", " ", -1)
+
+	if name == "" {
+		return info
+	}
+
+	// Trailing site tag, i.e. [eztv] or [YTS.MX]
+	var tag string
+	if m := reRelTag.FindStringSubmatchIndex(name); m != nil {
+		tag = name[m[2]:m[3]]
+		name = strings.TrimSpace(name[:m[0]])
+	}
+
+	if name == "" {
+		return info
+	}
+
+	end := len(name)
+	marker := func(idx []int) {
+		if idx != nil && idx[0] > 0 && idx[0] < end {
+			end = idx[0]
+		}
+	}
+
+	// Season and episode
+	if m := reRelEpisode.FindStringSubmatchIndex(name); m != nil {
+		info.Season, _ = strconv.Atoi(name[m[2]:m[3]])
+		info.Episode, _ = strconv.Atoi(name[m[4]:m[5]])
+		if more := reRelNumbers.FindAllString(name[m[6]:m[7]], -1); len(more) > 0 {
+			info.EpisodeEnd, _ = strconv.Atoi(more[len(more)-1])
+		}
+		marker(m)
+	} else if m := reRelEpisodeX.FindStringSubmatchIndex(name); m != nil {
+		info.Season, _ = strconv.Atoi(name[m[2]:m[3]])
+		info.Episode, _ = strconv.Atoi(name[m[4]:m[5]])
+		marker(m)
+	} else if m := reRelSeasonWord.FindStringSubmatchIndex(name); m != nil {
+		info.Season, _ = strconv.Atoi(name[m[2]:m[3]])
+		if m[4] != -1 {
+			info.SeasonEnd, _ = strconv.Atoi(name[m[4]:m[5]])
+		}
+		info.SeasonPack = true
+		marker(m)
+	} else if m := reRelSeasonPack.FindStringSubmatchIndex(name); m != nil {
+		info.Season, _ = strconv.Atoi(name[m[2]:m[3]])
+		if m[4] != -1 {
+			info.SeasonEnd, _ = strconv.Atoi(name[m[4]:m[5]])
+		}
+		info.SeasonPack = true
+		marker(m)
+	}
+
+	if m := reRelComplete.FindStringIndex(name); m != nil {
+		info.SeasonPack = true
+		marker(m)
+	}
+
+	if info.EpisodeEnd != 0 && info.EpisodeEnd <= info.Episode {
+		info.EpisodeEnd = 0
+	}
+	if info.SeasonEnd != 0 && info.SeasonEnd <= info.Season {
+		info.SeasonEnd = 0
+	}
+
+	// Resolution
+	if m := reRelResolution.FindStringSubmatchIndex(name); m != nil {
+		info.Resolution = name[m[2]:m[3]] + "p"
+		marker(m)
+	} else if m := reRelUHD.FindStringIndex(name); m != nil {
+		info.Resolution = "2160p"
+		marker(m)
+	}
+
+	// Year, the last one before other markers, so "2001 A Space Odyssey 1968" works
+	var year []int
+	for _, y := range reRelYear.FindAllStringSubmatchIndex(name, -1) {
+		if y[0] == 0 {
+			continue
+		}
+		if y[0] < end || year == nil {
+			year = y
+		}
+	}
+	if year != nil {
+		info.Year = name[year[2]:year[3]]
+		marker(year)
+	}
+
+	// Source and codec words can be part of the title, look after the year or episode when known
+	from := 1
+	if end < len(name) {
+		from = end
+	}
+
+	pos := -1
+	for _, r := range releaseSources {
+		for _, m := range r.re.FindAllStringIndex(name, -1) {
+			if m[0] >= from && (pos == -1 || m[0] < pos) {
+				pos = m[0]
+				info.Source = r.name
+			}
+		}
+	}
+	if pos != -1 {
+		marker([]int{pos})
+	}
+
+	for _, r := range releaseCodecs {
+		if m := r.re.FindStringIndex(name[from:]); m != nil {
+			info.Codec = r.name
+			marker([]int{m[0] + from})
+			break
+		}
+	}
+
+	info.Title = releaseTitle(name[:end])
+
+	if end == len(name) {
+		return info
+	}
+
+	tail := name[end:]
+
+	// HDR
+	if reRelHDR10Plus.MatchString(tail) {
+		info.HDR = "HDR10+"
+	} else if reRelHDR10.MatchString(tail) {
+		info.HDR = "HDR10"
+	} else if reRelHDR.MatchString(tail) {
+		info.HDR = "HDR"
+	}
+	info.DolbyVision = reRelDV.MatchString(tail)
+
+	// Audio
+	rest := tail
+	type found struct {
+		pos  int
+		name string
+	}
+	var audio []found
+	for _, r := range releaseAudio {
+		for _, m := range r.re.FindAllStringSubmatchIndex(rest, -1) {
+			a := r.name
+			if len(m) > 5 && m[2] != -1 && m[4] != -1 {
+				a += rest[m[2]:m[3]] + "." + rest[m[4]:m[5]]
+			}
+			audio = append(audio, found{m[0], a})
+			rest = rest[:m[0]] + strings.Repeat(" ", m[1]-m[0]) + rest[m[1]:]
+		}
+	}
+	sort.SliceStable(audio, func(i, j int) bool { return audio[i].pos < audio[j].pos })
+	for _, a := range audio {
+		if !inStrings(a.name, info.Audio) {
+			info.Audio = append(info.Audio, a.name)
+		}
+	}
+
+	// Languages
+	var langs []found
+	for _, r := range releaseLanguages {
+		if m := r.re.FindStringIndex(tail); m != nil {
+			langs = append(langs, found{m[0], r.name})
+		}
+	}
+	sort.SliceStable(langs, func(i, j int) bool { return langs[i].pos < langs[j].pos })
+	for _, l := range langs {
+		info.Languages = append(info.Languages, l.name)
+	}
+
+	info.Proper = reRelProper.MatchString(tail)
+	info.Repack = reRelRepack.MatchString(tail)
+
+	// Release group
+	if strings.HasSuffix(name, "-") && tag != "" {
+		info.Group = tag
+	} else if m := reRelGroup.FindStringSubmatchIndex(name); m != nil && m[0] >= end {
+		group := name[m[2]:m[3]]
+		if !inStrings(strings.ToLower(group), releaseNotGroups) {
+			info.Group = group
+		}
+	}
+	if info.Group == "" && tag != "" && !inStrings(strings.ToLower(tag), releaseSites) {
+		info.Group = tag
+	}
+
+	return info
+}
+
+// Site tags that are not release groups
+var releaseSites = []string{"eztv", "ettv", "rarbg", "tgx", "publichd"}
+
+// releaseTitle returns cleaned title from the part of release name before markers
+func releaseTitle(s string) string {
+	if !strings.Contains(strings.TrimSpace(s), " ") {
+		s = strings.Replace(s, ".", " ", -1)
+	}
+
+	s = strings.Trim(s, " .-([{")
+	s = reRelSpaces.ReplaceAllString(s, " ")
+
+	return s
+}
+
+// inStrings checks if string is in slice
+func inStrings(a string, b []string) bool {
+	for _, s := range b {
+		if a == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRelease returns parsed release name
+func ParseRelease(name string) (string, error) {
+	info := parseRelease(name)
+
+	js, err := json.MarshalIndent(info, "", "    ")
+	if err != nil {
+		return "empty", err
+	}
+
+	return string(js[:]), nil
+}
@@ -0,0 +1,372 @@
+package bukanir
+
+import (
+	"reflect"
+	"testing"
+)
+
+var releases = []struct {
+	name string
+	info TReleaseInfo
+}{
+	{
+		"Alien.Directors.Cut.1979.1080p.BRrip.x264.GAZ.YIFY",
+		TReleaseInfo{Title: "Alien Directors Cut", Year: "1979", Resolution: "1080p", Source: "BluRay", Codec: "x264"},
+	},
+	{
+		"Game.of.Thrones.S01E03.Lord.Snow.HDTV.XviD-FQM",
+		TReleaseInfo{Title: "Game of Thrones", Source: "HDTV", Codec: "XviD", Group: "FQM", Season: 1, Episode: 3},
+	},
+	{
+		"The.Matrix.1999.2160p.UHD.BluRay.x265.10bit.HDR.TrueHD.7.1.Atmos-TERMiNAL",
+		TReleaseInfo{Title: "The Matrix", Year: "1999", Resolution: "2160p", Source: "BluRay", Codec: "x265", HDR: "HDR",
+			Audio: []string{"TrueHD7.1", "Atmos"}, Group: "TERMiNAL"},
+	},
+	{
+		"Dune.Part.Two.2024.1080p.WEB-DL.DDP5.1.Atmos.H.264-FLUX",
+		TReleaseInfo{Title: "Dune Part Two", Year: "2024", Resolution: "1080p", Source: "WEB-DL", Codec: "x264",
+			Audio: []string{"DDP5.1", "Atmos"}, Group: "FLUX"},
+	},
+	{
+		"Oppenheimer.2023.2160p.WEB-DL.DDP5.1.Atmos.DV.HDR10.H.265-FLUX",
+		TReleaseInfo{Title: "Oppenheimer", Year: "2023", Resolution: "2160p", Source: "WEB-DL", Codec: "x265", HDR: "HDR10",
+			DolbyVision: true, Audio: []string{"DDP5.1", "Atmos"}, Group: "FLUX"},
+	},
+	{
+		"Avatar.The.Way.of.Water.2022.2160p.BluRay.REMUX.HEVC.DTS-HD.MA.7.1.HDR10+-FGT",
+		TReleaseInfo{Title: "Avatar The Way of Water", Year: "2022", Resolution: "2160p", Source: "BluRay", Codec: "x265",
+			HDR: "HDR10+", Audio: []string{"DTS-HD7.1"}, Group: "FGT"},
+	},
+	{
+		"The Batman (2022) [1080p] [WEBRip] [5.1] [YTS.MX]",
+		TReleaseInfo{Title: "The Batman", Year: "2022", Resolution: "1080p", Source: "WEBRip", Group: "YTS.MX"},
+	},
+	{
+		"Barbie.2023.HDCAM.x264-SUNSCREEN",
+		TReleaseInfo{Title: "Barbie", Year: "2023", Source: "CAM", Codec: "x264", Group: "SUNSCREEN"},
+	},
+	{
+		"Fast.X.2023.HDTS.x264.AAC-HushRips",
+		TReleaseInfo{Title: "Fast X", Year: "2023", Source: "TS", Codec: "x264", Audio: []string{"AAC"}, Group: "HushRips"},
+	},
+	{
+		"John.Wick.Chapter.4.2023.720p.TELESYNC.x264-COLLECTiVE",
+		TReleaseInfo{Title: "John Wick Chapter 4", Year: "2023", Resolution: "720p", Source: "TS", Codec: "x264", Group: "COLLECTiVE"},
+	},
+	{
+		"Old.Movie.1985.DVDRip.XviD.AC3-WAF",
+		TReleaseInfo{Title: "Old Movie", Year: "1985", Source: "DVDRip", Codec: "XviD", Audio: []string{"DD"}, Group: "WAF"},
+	},
+	{
+		"Some.Film.2012.480p.DVDScr.XviD.MP3-Kingdom",
+		TReleaseInfo{Title: "Some Film", Year: "2012", Resolution: "480p", Source: "SCR", Codec: "XviD", Audio: []string{"MP3"}, Group: "Kingdom"},
+	},
+	{
+		"Movie.Title.2019.576p.HDRip.x264.AAC2.0",
+		TReleaseInfo{Title: "Movie Title", Year: "2019", Resolution: "576p", Source: "HDRip", Codec: "x264", Audio: []string{"AAC2.0"}},
+	},
+	{
+		"2001.A.Space.Odyssey.1968.1080p.BluRay.x264",
+		TReleaseInfo{Title: "2001 A Space Odyssey", Year: "1968", Resolution: "1080p", Source: "BluRay", Codec: "x264"},
+	},
+	{
+		"Blade.Runner.2049.2017.1080p.BluRay.x264.DTS-HD.MA.7.1-SWTYBLZ",
+		TReleaseInfo{Title: "Blade Runner 2049", Year: "2017", Resolution: "1080p", Source: "BluRay", Codec: "x264",
+			Audio: []string{"DTS-HD7.1"}, Group: "SWTYBLZ"},
+	},
+	{
+		"1917.2019.1080p.BluRay.x264-SPARKS",
+		TReleaseInfo{Title: "1917", Year: "2019", Resolution: "1080p", Source: "BluRay", Codec: "x264", Group: "SPARKS"},
+	},
+	{
+		"Charlottes.Web.2006.DVDRip.XviD-DiAMOND",
+		TReleaseInfo{Title: "Charlottes Web", Year: "2006", Source: "DVDRip", Codec: "XviD", Group: "DiAMOND"},
+	},
+	{
+		"The.Italian.Job.2003.720p.BluRay.x264.ITA.ENG-Lux",
+		TReleaseInfo{Title: "The Italian Job", Year: "2003", Resolution: "720p", Source: "BluRay", Codec: "x264",
+			Languages: []string{"Italian", "English"}, Group: "Lux"},
+	},
+	{
+		"Der.Untergang.2004.German.DL.1080p.BluRay.x264-DETAiLS",
+		TReleaseInfo{Title: "Der Untergang", Year: "2004", Resolution: "1080p", Source: "BluRay", Codec: "x264",
+			Languages: []string{"German"}, Group: "DETAiLS"},
+	},
+	{
+		"Amelie.2001.MULTi.TRUEFRENCH.1080p.BluRay.x264.AC3-LOST",
+		TReleaseInfo{Title: "Amelie", Year: "2001", Resolution: "1080p", Source: "BluRay", Codec: "x264",
+			Audio: []string{"DD"}, Languages: []string{"Multi", "French"}, Group: "LOST"},
+	},
+	{
+		"Parasite.2019.KOREAN.1080p.BluRay.H264.AAC-VXT",
+		TReleaseInfo{Title: "Parasite", Year: "2019", Resolution: "1080p", Source: "BluRay", Codec: "x264",
+			Audio: []string{"AAC"}, Languages: []string{"Korean"}, Group: "VXT"},
+	},
+	{
+		"RRR.2022.DUAL.AUDIO.HINDI.1080p.WEBRip.x264",
+		TReleaseInfo{Title: "RRR", Year: "2022", Resolution: "1080p", Source: "WEBRip", Codec: "x264", Languages: []string{"Dual", "Hindi"}},
+	},
+	{
+		"Show.Name.S02E05.PROPER.720p.HDTV.x264-KILLERS",
+		TReleaseInfo{Title: "Show Name", Resolution: "720p", Source: "HDTV", Codec: "x264", Proper: true, Group: "KILLERS", Season: 2, Episode: 5},
+	},
+	{
+		"Show.Name.S02E05.REPACK.1080p.WEB.h264-TRUMP",
+		TReleaseInfo{Title: "Show Name", Resolution: "1080p", Source: "WEB-DL", Codec: "x264", Repack: true, Group: "TRUMP", Season: 2, Episode: 5},
+	},
+	{
+		"Show.Name.S02E05.REAL.PROPER.REPACK.720p.WEB.x264-GROUP",
+		TReleaseInfo{Title: "Show Name", Resolution: "720p", Source: "WEB-DL", Codec: "x264", Proper: true, Repack: true, Group: "GROUP", Season: 2, Episode: 5},
+	},
+	{
+		"The.Office.US.S05E14E15.720p.WEB-DL.DD5.1.H.264-NTb",
+		TReleaseInfo{Title: "The Office US", Resolution: "720p", Source: "WEB-DL", Codec: "x264", Audio: []string{"DD5.1"},
+			Group: "NTb", Season: 5, Episode: 14, EpisodeEnd: 15},
+	},
+	{
+		"Lost.S01E01-E02.Pilot.1080p.BluRay.x264-ROVERS",
+		TReleaseInfo{Title: "Lost", Resolution: "1080p", Source: "BluRay", Codec: "x264", Group: "ROVERS", Season: 1, Episode: 1, EpisodeEnd: 2},
+	},
+	{
+		"Friends.S03E24-25.720p.BluRay.x264",
+		TReleaseInfo{Title: "Friends", Resolution: "720p", Source: "BluRay", Codec: "x264", Season: 3, Episode: 24, EpisodeEnd: 25},
+	},
+	{
+		"Doctor.Who.2005.S01E01.Rose.DVDRip.XviD",
+		TReleaseInfo{Title: "Doctor Who", Year: "2005", Source: "DVDRip", Codec: "XviD", Season: 1, Episode: 1},
+	},
+	{
+		"The Simpsons 12x05 HDTV",
+		TReleaseInfo{Title: "The Simpsons", Source: "HDTV", Season: 12, Episode: 5},
+	},
+	{
+		"Breaking.Bad.S05.1080p.BluRay.x265-RARBG",
+		TReleaseInfo{Title: "Breaking Bad", Resolution: "1080p", Source: "BluRay", Codec: "x265", Group: "RARBG", Season: 5, SeasonPack: true},
+	},
+	{
+		"Breaking.Bad.S01-S05.720p.BluRay.x264",
+		TReleaseInfo{Title: "Breaking Bad", Resolution: "720p", Source: "BluRay", Codec: "x264", Season: 1, SeasonEnd: 5, SeasonPack: true},
+	},
+	{
+		"The Wire Season 1-5 Complete 720p BluRay x264",
+		TReleaseInfo{Title: "The Wire", Resolution: "720p", Source: "BluRay", Codec: "x264", Season: 1, SeasonEnd: 5, SeasonPack: true},
+	},
+	{
+		"Seinfeld Season 3 DVDRip XviD",
+		TReleaseInfo{Title: "Seinfeld", Source: "DVDRip", Codec: "XviD", Season: 3, SeasonPack: true},
+	},
+	{
+		"Firefly Complete Series 1080p BluRay x265 HEVC",
+		TReleaseInfo{Title: "Firefly", Resolution: "1080p", Source: "BluRay", Codec: "x265", SeasonPack: true},
+	},
+	{
+		"Mr. Robot S01E01 720p HDTV x264-KILLERS [eztv]",
+		TReleaseInfo{Title: "Mr. Robot", Resolution: "720p", Source: "HDTV", Codec: "x264", Group: "KILLERS", Season: 1, Episode: 1},
+	},
+	{
+		"Show_Name_S01E02_480p_x264-mSD",
+		TReleaseInfo{Title: "Show Name", Resolution: "480p", Codec: "x264", Group: "mSD", Season: 1, Episode: 2},
+	},
+	{
+		"Movie.Title.2020.1920x1080.BluRay.x264.FLAC.2.0",
+		TReleaseInfo{Title: "Movie Title", Year: "2020", Resolution: "1080p", Source: "BluRay", Codec: "x264", Audio: []string{"FLAC2.0"}},
+	},
+	{
+		"Movie.Title.2021.4K.WEB.AV1.Opus-GROUP",
+		TReleaseInfo{Title: "Movie Title", Year: "2021", Resolution: "2160p", Source: "WEB-DL", Codec: "AV1", Audio: []string{"Opus"}, Group: "GROUP"},
+	},
+	{
+		"Movie.Title.2021.1080p.WEBRip.VP9.EAC3.5.1-GROUP",
+		TReleaseInfo{Title: "Movie Title", Year: "2021", Resolution: "1080p", Source: "WEBRip", Codec: "VP9", Audio: []string{"DDP5.1"}, Group: "GROUP"},
+	},
+	{
+		"Movie.Title.2018.1080p.BluRay.DTS.x264-GROUP.mkv",
+		TReleaseInfo{Title: "Movie Title", Year: "2018", Resolution: "1080p", Source: "BluRay", Codec: "x264", Audio: []string{"DTS"}, Group: "GROUP"},
+	},
+	{
+		"Movie.Title.2018.RUSSIAN.720p.WEB-DL.DD+5.1.x264",
+		TReleaseInfo{Title: "Movie Title", Year: "2018", Resolution: "720p", Source: "WEB-DL", Codec: "x264",
+			Audio: []string{"DDP5.1"}, Languages: []string{"Russian"}},
+	},
+	{
+		"Spider-Man.No.Way.Home.2021.1080p.WEB-DL.x264",
+		TReleaseInfo{Title: "Spider-Man No Way Home", Year: "2021", Resolution: "1080p", Source: "WEB-DL", Codec: "x264"},
+	},
+	{
+		"Spider-Man",
+		TReleaseInfo{Title: "Spider-Man"},
+	},
+	{
+		"2012 2009 720p BRRip",
+		TReleaseInfo{Title: "2012", Year: "2009", Resolution: "720p", Source: "BluRay"},
+	},
+	{
+		"Planet.Earth.II.2016.2160p.UHD.BluRay.HDR.DoVi.TrueHD.Atmos-GROUP",
+		TReleaseInfo{Title: "Planet Earth II", Year: "2016", Resolution: "2160p", Source: "BluRay", HDR: "HDR", DolbyVision: true,
+			Audio: []string{"TrueHD", "Atmos"}, Group: "GROUP"},
+	},
+	{
+		"[eztv]",
+		TReleaseInfo{},
+	},
+	{
+		"[x]",
+		TReleaseInfo{},
+	},
+	{
+		"The.Matrix.1999.1080p.BluRay.x264.DTS-FGT",
+		TReleaseInfo{Title: "The Matrix", Year: "1999", Resolution: "1080p", Source: "BluRay", Codec: "x264",
+			Audio: []string{"DTS"}, Group: "FGT"},
+	},
+	{
+		"Inception.2010.720p.BluRay.x264.YIFY",
+		TReleaseInfo{Title: "Inception", Year: "2010", Resolution: "720p", Source: "BluRay", Codec: "x264"},
+	},
+	{
+		"Interstellar.2014.2160p.UHD.BluRay.x265.10bit.HDR.DTS-HD.MA.5.1-SWTYBLZ",
+		TReleaseInfo{Title: "Interstellar", Year: "2014", Resolution: "2160p", Source: "BluRay", Codec: "x265", HDR: "HDR",
+			Audio: []string{"DTS-HD5.1"}, Group: "SWTYBLZ"},
+	},
+	{
+		"Oppenheimer.2023.1080p.WEBRip.x265.10bit.AAC5.1-LAMA",
+		TReleaseInfo{Title: "Oppenheimer", Year: "2023", Resolution: "1080p", Source: "WEBRip", Codec: "x265",
+			Audio: []string{"AAC5.1"}, Group: "LAMA"},
+	},
+	{
+		"Parasite.2019.KOREAN.1080p.BluRay.H264.AAC-VXT",
+		TReleaseInfo{Title: "Parasite", Year: "2019", Resolution: "1080p", Source: "BluRay", Codec: "x264",
+			Audio: []string{"AAC"}, Languages: []string{"Korean"}, Group: "VXT"},
+	},
+	{
+		"The.Mandalorian.S02E08.2160p.DSNP.WEB-DL.DDP5.1.Atmos.HDR.HEVC-MZABI",
+		TReleaseInfo{Title: "The Mandalorian", Resolution: "2160p", Source: "WEB-DL", Codec: "x265", HDR: "HDR",
+			Audio: []string{"DDP5.1", "Atmos"}, Group: "MZABI", Season: 2, Episode: 8},
+	},
+	{
+		"House.of.the.Dragon.S01E01.1080p.WEB.H264-CAKES[TGx]",
+		TReleaseInfo{Title: "House of the Dragon", Resolution: "1080p", Source: "WEB-DL", Codec: "x264", Group: "CAKES",
+			Season: 1, Episode: 1},
+	},
+	{
+		"Stranger.Things.S04E01.720p.NF.WEB-DL.DDP5.1.x264-NTb",
+		TReleaseInfo{Title: "Stranger Things", Resolution: "720p", Source: "WEB-DL", Codec: "x264", Audio: []string{"DDP5.1"},
+			Group: "NTb", Season: 4, Episode: 1},
+	},
+	{
+		"The.Last.of.Us.S01E09.WEB.x264-TORRENTGALAXY",
+		TReleaseInfo{Title: "The Last of Us", Source: "WEB-DL", Codec: "x264", Group: "TORRENTGALAXY", Season: 1, Episode: 9},
+	},
+	{
+		"Succession.S04E10.1080p.HEVC.x265-MeGusta",
+		TReleaseInfo{Title: "Succession", Resolution: "1080p", Codec: "x265", Group: "MeGusta", Season: 4, Episode: 10},
+	},
+	{
+		"Barbie.2023.HDCAM.x264-SUNSCREEN",
+		TReleaseInfo{Title: "Barbie", Year: "2023", Source: "CAM", Codec: "x264", Group: "SUNSCREEN"},
+	},
+	{
+		"Joker.2019.HDRip.XviD.AC3-EVO",
+		TReleaseInfo{Title: "Joker", Year: "2019", Source: "HDRip", Codec: "XviD", Audio: []string{"DD"}, Group: "EVO"},
+	},
+	{
+		"Dune.Part.Two.2024.1080p.WEB-DL.DDP5.1.Atmos.H.264-FLUX",
+		TReleaseInfo{Title: "Dune Part Two", Year: "2024", Resolution: "1080p", Source: "WEB-DL", Codec: "x264",
+			Audio: []string{"DDP5.1", "Atmos"}, Group: "FLUX"},
+	},
+	{
+		"The.Godfather.1972.REMASTERED.1080p.BluRay.x264-AMIABLE",
+		TReleaseInfo{Title: "The Godfather", Year: "1972", Resolution: "1080p", Source: "BluRay", Codec: "x264", Group: "AMIABLE"},
+	},
+	{
+		"Amelie.2001.FRENCH.720p.BluRay.x264-LOST",
+		TReleaseInfo{Title: "Amelie", Year: "2001", Resolution: "720p", Source: "BluRay", Codec: "x264",
+			Languages: []string{"French"}, Group: "LOST"},
+	},
+	{
+		"Top Gun Maverick (2022) [1080p] [WEBRip] [5.1] [YTS.MX]",
+		TReleaseInfo{Title: "Top Gun Maverick", Year: "2022", Resolution: "1080p", Source: "WEBRip", Group: "YTS.MX"},
+	},
+	{
+		"Blade Runner 2049 (2017) 1080p BluRay x264 -[YTS.AM]",
+		TReleaseInfo{Title: "Blade Runner 2049", Year: "2017", Resolution: "1080p", Source: "BluRay", Codec: "x264", Group: "YTS.AM"},
+	},
+	{
+		"The.Crown.S05.COMPLETE.720p.NF.WEBRip.x264-GalaxyTV",
+		TReleaseInfo{Title: "The Crown", Resolution: "720p", Source: "WEBRip", Codec: "x264", Group: "GalaxyTV", Season: 5,
+			SeasonPack: true},
+	},
+	{
+		"Arcane.S01E01.1080p.NF.WEB-DL.DDP5.1.x264-TEPES",
+		TReleaseInfo{Title: "Arcane", Resolution: "1080p", Source: "WEB-DL", Codec: "x264", Audio: []string{"DDP5.1"},
+			Group: "TEPES", Season: 1, Episode: 1},
+	},
+	{
+		"Seinfeld.S09E23E24.The.Finale.DVDRip.XviD-SAiNTS",
+		TReleaseInfo{Title: "Seinfeld", Source: "DVDRip", Codec: "XviD", Group: "SAiNTS", Season: 9, Episode: 23, EpisodeEnd: 24},
+	},
+	{
+		"The.Boys.S03E01.REPACK.1080p.AMZN.WEB-DL.DDP5.1.H.264-NTb",
+		TReleaseInfo{Title: "The Boys", Resolution: "1080p", Source: "WEB-DL", Codec: "x264", Audio: []string{"DDP5.1"},
+			Repack: true, Group: "NTb", Season: 3, Episode: 1},
+	},
+	{
+		"Avatar.The.Way.of.Water.2022.2160p.WEB-DL.DDP5.1.Atmos.DV.HDR.H.265-FLUX",
+		TReleaseInfo{Title: "Avatar The Way of Water", Year: "2022", Resolution: "2160p", Source: "WEB-DL", Codec: "x265",
+			HDR: "HDR", DolbyVision: true, Audio: []string{"DDP5.1", "Atmos"}, Group: "FLUX"},
+	},
+	{
+		"Shogun.2024.S01E01.1080p.WEB.h264-ETHEL",
+		TReleaseInfo{Title: "Shogun", Year: "2024", Resolution: "1080p", Source: "WEB-DL", Codec: "x264", Group: "ETHEL",
+			Season: 1, Episode: 1},
+	},
+	{
+		"Fargo.S05E01.720p.HDTV.x264-SYNCOPY",
+		TReleaseInfo{Title: "Fargo", Resolution: "720p", Source: "HDTV", Codec: "x264", Group: "SYNCOPY", Season: 5, Episode: 1},
+	},
+	{
+		"Pulp.Fiction.1994.PROPER.1080p.BluRay.x264-SiNNERS",
+		TReleaseInfo{Title: "Pulp Fiction", Year: "1994", Resolution: "1080p", Source: "BluRay", Codec: "x264", Proper: true,
+			Group: "SiNNERS"},
+	},
+	{
+		"Se7en.1995.720p.BRRip.x264.AAC-ETRG",
+		TReleaseInfo{Title: "Se7en", Year: "1995", Resolution: "720p", Source: "BluRay", Codec: "x264", Audio: []string{"AAC"},
+			Group: "ETRG"},
+	},
+	{
+		"1917.2019.1080p.BluRay.x264-SPARKS",
+		TReleaseInfo{Title: "1917", Year: "2019", Resolution: "1080p", Source: "BluRay", Codec: "x264", Group: "SPARKS"},
+	},
+	{
+		"The.Office.S01-S09.1080p.BluRay.x265",
+		TReleaseInfo{Title: "The Office", Resolution: "1080p", Source: "BluRay", Codec: "x265", Season: 1, SeasonEnd: 9, SeasonPack: true},
+	},
+	{
+		"",
+		TReleaseInfo{},
+	},
+}
+
+func TestParseRelease(t *testing.T) {
+	for _, r := range releases {
+		info := parseRelease(r.name)
+		if !reflect.DeepEqual(info, r.info) {
+			t.Errorf("%q:\n got %+v\nwant %+v", r.name, info, r.info)
+		}
+	}
+}
+
+func TestGetQuality(t *testing.T) {
+	qualities := map[string]string{
+		"Alien.Directors.Cut.1979.1080p.BRrip.x264.GAZ.YIFY": "1080",
+		"The.Matrix.1999.2160p.UHD.BluRay.x265":              "2160",
+		"Game.of.Thrones.S01E03.Lord.Snow.HDTV.XviD-FQM":     "",
+		"Show_Name_S01E02_480p_x264-mSD":                     "480",
+	}
+
+	for name, quality := range qualities {
+		if q := getQuality(name); q != quality {
+			t.Errorf("%q: got %q, want %q", name, q, quality)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package bukanir
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+)
+
+// ShowSeasons returns seasons of tv show
+func (c *Client) ShowSeasons(ctx context.Context, id int) ([]TSeason, error) {
+	seasons := make([]TSeason, 0)
+	md := NewTmdb(ctx)
+
+	config, err := md.GetConfig()
+	if err != nil {
+		log.Printf("ERROR: TMDB GetConfig: %s\n", err.Error())
+		return seasons, metadataError(err)
+	}
+
+	res, err := md.GetTvDetails(strconv.Itoa(id), -1)
+	if err != nil {
+		log.Printf("ERROR: GetTvDetails: %v\n", err.Error())
+		return seasons, metadataError(err)
+	}
+
+	for _, s := range res.Seasons {
+		season := TSeason{
+			s.Season_number,
+			s.Name,
+			s.Air_date,
+			s.Episode_count,
+			s.Overview,
+			imageUrl(config, config.Images.Poster_sizes, 1, s.Poster_path),
+		}
+		seasons = append(seasons, season)
+	}
+
+	if len(seasons) == 0 {
+		return seasons, ErrNoResults
+	}
+
+	return seasons, nil
+}
+
+// SeasonEpisodes returns episodes of tv show season, each with the best available torrent by preference profile
+func (c *Client) SeasonEpisodes(ctx context.Context, id int, season int, pages int, tpbHost, eztvHost string, f TFilter) ([]TEpisode, error) {
+	episodes := make([]TEpisode, 0)
+	md := NewTmdb(ctx)
+
+	config, err := md.GetConfig()
+	if err != nil {
+		log.Printf("ERROR: TMDB GetConfig: %s\n", err.Error())
+		return episodes, metadataError(err)
+	}
+
+	show, err := md.GetTvDetails(strconv.Itoa(id), -1)
+	if err != nil {
+		log.Printf("ERROR: GetTvDetails: %v\n", err.Error())
+		return episodes, metadataError(err)
+	}
+
+	res, err := md.GetTvDetails(strconv.Itoa(id), season)
+	if err != nil {
+		log.Printf("ERROR: GetTvDetails season: %v\n", err.Error())
+		return episodes, metadataError(err)
+	}
+
+	if len(res.Episodes) == 0 {
+		return episodes, ErrNoResults
+	}
+
+	name := md.englishTitle(tmdbResult{Id: id, Name: show.Name, Original_name: show.Original_name, Original_language: show.Original_language}, true)
+
+	releases := c.seasonReleases(ctx, name, season, pages, tpbHost, eztvHost, f)
+	if ctx.Err() != nil {
+		return episodes, ctx.Err()
+	}
+
+	_, p := c.ranking()
+
+	for _, e := range res.Episodes {
+		episode := TEpisode{
+			e.Season_number,
+			e.Episode_number,
+			e.Name,
+			e.Air_date,
+			e.Overview,
+			imageUrl(config, config.Images.Still_sizes, 1, e.Still_path),
+			nil,
+		}
+
+		if rs, ok := releases[e.Episode_number]; ok {
+			r := rs[p.best(rs)]
+			episode.Torrent = &r
+		}
+
+		episodes = append(episodes, episode)
+	}
+
+	return episodes, nil
+}
+
+// seasonReleases returns releases of show season by episode number, name is english show name, season packs are skipped
+func (c *Client) seasonReleases(ctx context.Context, name string, season int, pages int, tpbHost, eztvHost string, f TFilter) map[int][]TRelease {
+	cl := newCall(ctx)
+
+	if tpbHost == "" {
+		tpbHost = getTpbHost()
+	}
+
+	if eztvHost == "" {
+		eztvHost = getEztvHost()
+	}
+
+	if pages < 0 {
+		pages = 0
+	}
+
+	query := fmt.Sprintf("%s S%02d", name, season)
+
+	cl.wgt.Add(pages + 1)
+	for n := 0; n < pages; n++ {
+		go cl.tpbSearch(query, n, tpbHost, "episodes")
+	}
+	go cl.eztvSearch(name, eztvHost)
+	cl.wgt.Wait()
+
+	title := getTitle(name)
+	releases := make(map[int][]TRelease)
+
+	for _, t := range filterTorrents(cl.torrents, f) {
+		if t.Season != season || t.Episode == 0 || getTitle(t.FormattedTitle) != title {
+			continue
+		}
+
+		r := TRelease{
+			t.Title,
+			t.MagnetLink,
+			t.Size,
+			t.SizeHuman,
+			t.Seeders,
+			getQuality(t.Title),
+			t.Provider,
+			parseRelease(t.Title),
+		}
+		releases[t.Episode] = append(releases[t.Episode], r)
+	}
+
+	for n := range releases {
+		sort.Stable(ByRSeeders(releases[n]))
+	}
+
+	if verbose {
+		log.Printf("BUK: %s season %d, %d episodes with torrents\n", name, season, len(releases))
+	}
+
+	return releases
+}
+
+// seasonEpisode returns episode of season details by episode number, false for season packs and missing episodes
+func seasonEpisode(season tmdbMovie, episode int) (tmdbEpisode, bool) {
+	if episode <= 0 || episode > len(season.Episodes) {
+		return tmdbEpisode{}, false
+	}
+
+	return season.Episodes[episode-1], true
+}
+
+// imageUrl returns image url for size index, empty path returns empty url
+func imageUrl(config *tmdbConfig, sizes []string, n int, path string) string {
+	if path == "" || len(sizes) == 0 {
+		return ""
+	}
+
+	if n >= len(sizes) {
+		n = len(sizes) - 1
+	}
+
+	return config.Images.Base_url + sizes[n] + path
+}
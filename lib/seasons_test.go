@@ -0,0 +1,52 @@
+package bukanir
+
+import (
+	"context"
+	"testing"
+)
+
+func TestShowSeasons(t *testing.T) {
+	c := NewClient()
+
+	seasons, err := c.ShowSeasons(context.Background(), teId)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(seasons) == 0 {
+		t.Error("no seasons")
+	}
+}
+
+func TestSeasonEpisodes(t *testing.T) {
+	c := NewClient()
+
+	episodes, err := c.SeasonEpisodes(context.Background(), teId, 1, 1, "", "", DefaultFilter())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(episodes) == 0 {
+		t.Error("no episodes")
+	}
+
+	for _, e := range episodes {
+		if e.Torrent != nil && e.Torrent.MagnetLink == "" {
+			t.Errorf("episode %d: torrent without magnet link", e.Episode)
+		}
+	}
+}
+
+func TestSeasonEpisode(t *testing.T) {
+	season := tmdbMovie{Episodes: []tmdbEpisode{{Name: "Pilot"}, {Name: "Second"}}}
+
+	if e, ok := seasonEpisode(season, 2); !ok || e.Name != "Second" {
+		t.Errorf("episode 2 = %q, %v, want Second", e.Name, ok)
+	}
+
+	for _, episode := range []int{0, -1, 3} {
+		if _, ok := seasonEpisode(season, episode); ok {
+			t.Errorf("episode %d found", episode)
+		}
+	}
+}
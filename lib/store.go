@@ -0,0 +1,108 @@
+package bukanir
+
+import (
+	"crypto/md5"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Metadata store directory, empty directory disables the store
+var (
+	storeMu  sync.RWMutex
+	storeDir string
+)
+
+// Expiry of stored metadata by type, stale entries are still used when network is down
+var storeTTL = map[string]time.Duration{
+	"config":        3 * 24 * time.Hour,
+	"genres":        7 * 24 * time.Hour,
+	"movie":         7 * 24 * time.Hour,
+	"tv":            24 * time.Hour,
+	"season":        24 * time.Hour,
+	"externals":     30 * 24 * time.Hour,
+	"alternative":   30 * 24 * time.Hour,
+	"images":        7 * 24 * time.Hour,
+	"list":          24 * time.Hour,
+	"person":        7 * 24 * time.Hour,
+	"certification": 30 * 24 * time.Hour,
+}
+
+// SetMetadataDir sets directory of persistent TMDB metadata store, empty string disables the store
+func SetMetadataDir(dir string) {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	storeDir = dir
+}
+
+// storeFile returns file for metadata type and key
+func storeFile(kind string, key string) string {
+	storeMu.RLock()
+	defer storeMu.RUnlock()
+
+	if storeDir == "" {
+		return ""
+	}
+
+	return filepath.Join(storeDir, kind, fmt.Sprintf("%x.json", md5.Sum([]byte(key))))
+}
+
+// storeGet returns stored metadata and checks if it is fresh
+func storeGet(kind string, key string) (body []byte, fresh bool, ok bool) {
+	file := storeFile(kind, key)
+	if file == "" {
+		return nil, false, false
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, false, false
+	}
+
+	body, err = ioutil.ReadFile(file)
+	if err != nil {
+		log.Printf("ERROR: ReadFile %s: %s\n", file, err.Error())
+		return nil, false, false
+	}
+
+	return body, time.Since(info.ModTime()) < storeTTL[kind], true
+}
+
+// storePut saves metadata, file is replaced atomically
+func storePut(kind string, key string, body []byte) {
+	file := storeFile(kind, key)
+	if file == "" {
+		return
+	}
+
+	err := os.MkdirAll(filepath.Dir(file), 0777)
+	if err != nil {
+		log.Printf("ERROR: MkdirAll %s: %s\n", filepath.Dir(file), err.Error())
+		return
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(file), "tmp")
+	if err != nil {
+		log.Printf("ERROR: TempFile: %s\n", err.Error())
+		return
+	}
+
+	_, err = tmp.Write(body)
+	tmp.Close()
+	if err != nil {
+		log.Printf("ERROR: Write %s: %s\n", tmp.Name(), err.Error())
+		os.Remove(tmp.Name())
+		return
+	}
+
+	err = os.Rename(tmp.Name(), file)
+	if err != nil {
+		log.Printf("ERROR: Rename %s: %s\n", tmp.Name(), err.Error())
+		os.Remove(tmp.Name())
+	}
+}
@@ -0,0 +1,53 @@
+package bukanir
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStoreStale(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "bukanir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	SetMetadataDir(dir)
+	defer SetMetadataDir("")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1}`))
+	}))
+
+	uri := ts.URL + "/movie/1"
+	md := NewTmdb(context.Background())
+
+	body, err := md.getStored("movie", uri)
+	if err != nil || string(body) != `{"id": 1}` || md.stale {
+		t.Fatalf("got %s, %v, stale %v", body, err, md.stale)
+	}
+
+	ts.Close()
+
+	// Expire stored entry
+	file := storeFile("movie", uri)
+	old := time.Now().Add(-storeTTL["movie"] - time.Hour)
+	err = os.Chtimes(file, old, old)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err = md.getStored("movie", uri)
+	if err != nil || string(body) != `{"id": 1}` {
+		t.Errorf("got %s, %v, want stale body", body, err)
+	}
+
+	if !md.stale {
+		t.Error("tmdb should be marked stale")
+	}
+}
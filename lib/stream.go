@@ -0,0 +1,125 @@
+package bukanir
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+)
+
+// Callback interface, receives search results as they are found, compatible with gomobile
+type Callback interface {
+	// OnResult is called with json of each movie found
+	OnResult(movie string)
+	// OnProgress is called after each TMDB lookup with number of resolved torrents
+	OnProgress(done int, total int)
+	// OnDone is called when search is finished, err is nil on success
+	OnDone(err error)
+}
+
+// stream type, delivers results of a call as they are found, mu serializes delivery
+type stream struct {
+	mu sync.Mutex
+
+	weights TWeights
+	profile TProfile
+	filter  TFilter
+
+	result   func(TMovie)
+	progress func(done, total int)
+
+	done, total int
+}
+
+// addMovie appends movie to call results and sends it to stream if it passes IMDb filter and parental controls,
+// stream is called without holding call lock
+func (c *call) addMovie(m TMovie) {
+	c.Lock()
+	c.movies = append(c.movies, m)
+	s := c.stream
+	c.Unlock()
+
+	if s == nil || s.result == nil || len(filterMovies([]TMovie{m}, s.filter)) == 0 {
+		return
+	}
+
+	if !c.allowed(m.Id, m.Category == CategoryTV || m.Category == CategoryHDTV) {
+		return
+	}
+
+	movs := []TMovie{m}
+	rankMovies(movs, s.weights, s.profile)
+
+	s.mu.Lock()
+	s.result(movs[0])
+	s.mu.Unlock()
+}
+
+// advance reports n torrents resolved by finished TMDB lookup to stream
+func (c *call) advance(n int) {
+	c.Lock()
+	s := c.stream
+	if s == nil {
+		c.Unlock()
+		return
+	}
+
+	s.done += n
+	done, total := s.done, s.total
+	c.Unlock()
+
+	if s.progress != nil {
+		s.mu.Lock()
+		s.progress(done, total)
+		s.mu.Unlock()
+	}
+}
+
+// SearchStream is like Search, but sends each movie to results as soon as its TMDB lookup finishes.
+// Movies are sent unsorted, results channel is closed when search is done.
+func (c *Client) SearchStream(ctx context.Context, query string, limit int, force int, cacheDir string, cacheDays int64, pages int, tpbHost, eztvHost, sortBy, media string, f TFilter, results chan<- TMovie) ([]TMovie, error) {
+	defer close(results)
+
+	s := &stream{
+		result: func(m TMovie) {
+			select {
+			case results <- m:
+			case <-ctx.Done():
+			}
+		},
+	}
+
+	return c.search(ctx, query, limit, force, cacheDir, cacheDays, pages, tpbHost, eztvHost, sortBy, media, f, s)
+}
+
+// SearchStream is like Search, but returns immediately and delivers results through callback
+func (h *Handle) SearchStream(query string, limit int, force int, cacheDir string, cacheDays int64, pages int, tpbHost, eztvHost, sortBy, media, filter string, cb Callback) {
+	f, err := getFilter(filter)
+	if err != nil {
+		cb.OnDone(err)
+		return
+	}
+
+	s := &stream{
+		result: func(m TMovie) {
+			js, err := json.MarshalIndent(m, "", "    ")
+			if err != nil {
+				log.Printf("ERROR: Marshal: %s\n", err.Error())
+				return
+			}
+
+			cb.OnResult(string(js[:]))
+		},
+		progress: cb.OnProgress,
+	}
+
+	go func() {
+		_, err := defaultClient.search(h.ctx, query, limit, force, cacheDir, cacheDays, pages, tpbHost, eztvHost, sortBy, media, f, s)
+		if errors.Is(err, ErrNoResults) {
+			err = nil
+		}
+
+		cb.OnDone(err)
+	}()
+}
@@ -80,8 +80,8 @@ var languages = []language{
 }
 
 // podnapisi search for subtitles on podnapisi.net
-func podnapisi(movie string, year string, torrentRelease string, lang string, category int, season int, episode int) {
-	defer wgs.Done()
+func (c *call) podnapisi(movie string, year string, torrentRelease string, lang string, category int, season int, episode int) {
+	defer c.wgs.Done()
 	defer func() {
 		if r := recover(); r != nil {
 			log.Print("SUB: Recovered in podnapisi")
@@ -129,7 +129,7 @@ func podnapisi(movie string, year string, torrentRelease string, lang string, ca
 		log.Printf("SUB: GET %s\n", uri)
 	}
 
-	res, err := getResponse(uri)
+	res, err := getResponse(c.ctx, uri)
 	if err != nil {
 		log.Printf("ERROR: getResponse %s\n", err.Error())
 		return
@@ -181,13 +181,15 @@ func podnapisi(movie string, year string, torrentRelease string, lang string, ca
 		downloadLink := fmt.Sprintf("http://podnapisi.net/subtitles/%s/download", s.Pid)
 
 		s := TSubtitle{strconv.Itoa(s.Id), s.Title, s.Year, subtitleRelease, downloadLink, score}
-		subtitles = append(subtitles, s)
+		c.Lock()
+		c.subtitles = append(c.subtitles, s)
+		c.Unlock()
 	}
 }
 
 // opensubtitles search for subtitles on opensubtitles.org
-func opensubtitles(movie string, imdbId string, year string, torrentRelease string, lang string, category int, season int, episode int) {
-	defer wgs.Done()
+func (c *call) opensubtitles(movie string, imdbId string, year string, torrentRelease string, lang string, category int, season int, episode int) {
+	defer c.wgs.Done()
 	defer func() {
 		if r := recover(); r != nil {
 			log.Print("SUB: Recovered in opensubtitles")
@@ -199,13 +201,14 @@ func opensubtitles(movie string, imdbId string, year string, torrentRelease stri
 		log.Printf("ERROR: NewClient: %s\n", err.Error())
 	}
 
-	err = o.LogIn(opensubsUser, opensubsPassword, "en")
+	creds := getCredentials()
+	o.UserAgent = creds.OpensubsUserAgent
+
+	err = o.LogIn(creds.OpensubsUser, creds.OpensubsPassword, "en")
 	if err != nil {
-		log.Printf("ERROR: LogIn: %s\n", err.Error())
+		log.Printf("ERROR: LogIn: %s\n", redact(err.Error()))
 	}
 
-	o.UserAgent = opensubsUserAgent
-
 	params := []interface{}{
 		o.Token,
 		[]map[string]string{},
@@ -266,14 +269,16 @@ func opensubtitles(movie string, imdbId string, year string, torrentRelease stri
 		}
 
 		s := TSubtitle{sub.IDSubtitleFile, sub.MovieName, sub.MovieYear, sub.MovieReleaseName, sub.ZipDownloadLink, score}
-		subtitles = append(subtitles, s)
+		c.Lock()
+		c.subtitles = append(c.subtitles, s)
+		c.Unlock()
 	}
 
 }
 
 // subscene search for subtitles on subscene.com
-func subscene(movie string, year string, torrentRelease string, lang string, category int, season int, episode int) {
-	defer wgs.Done()
+func (c *call) subscene(movie string, year string, torrentRelease string, lang string, category int, season int, episode int) {
+	defer c.wgs.Done()
 	defer func() {
 		if r := recover(); r != nil {
 			log.Print("SUB: Recovered in subscene")
@@ -288,7 +293,7 @@ func subscene(movie string, year string, torrentRelease string, lang string, cat
 		log.Printf("SUB: GET %s\n", uri)
 	}
 
-	doc, err := getDocument(uri)
+	doc, err := getDocument(c.ctx, uri)
 	if err != nil {
 		log.Printf("ERROR: getDocument %s", err.Error())
 		return
@@ -303,6 +308,7 @@ func subscene(movie string, year string, torrentRelease string, lang string, cat
 	subs := make([]TSubtitle, 0)
 
 	var w sync.WaitGroup
+	var mu sync.Mutex
 	parseHTML := func(i int, td *goquery.Selection) {
 		defer w.Done()
 		defer func() {
@@ -351,7 +357,9 @@ func subscene(movie string, year string, torrentRelease string, lang string, cat
 		}
 
 		s := TSubtitle{id, subtitleTitle, subtitleYear, subtitleRelease, href, score}
+		mu.Lock()
 		subs = append(subs, s)
+		mu.Unlock()
 	}
 
 	w.Add(tds.Length())
@@ -367,7 +375,7 @@ func subscene(movie string, year string, torrentRelease string, lang string, cat
 	sort.Sort(ByScore(subs))
 	s := subs[0]
 
-	d, err := getDocument("http://subscene.com" + s.DownloadLink)
+	d, err := getDocument(c.ctx, "http://subscene.com"+s.DownloadLink)
 	if err != nil {
 		log.Printf("ERROR: getDocument %s\n", err.Error())
 		return
@@ -377,7 +385,9 @@ func subscene(movie string, year string, torrentRelease string, lang string, cat
 	downloadLink, _ := downloadHref.Attr("href")
 
 	sub := TSubtitle{"0", s.Title, s.Year, s.Release, "http://subscene.com" + downloadLink, s.Score}
-	subtitles = append(subtitles, sub)
+	c.Lock()
+	c.subtitles = append(c.subtitles, sub)
+	c.Unlock()
 }
 
 // getSubScore returns subtitle score
@@ -1,6 +1,7 @@
 package bukanir
 
 import (
+	"context"
 	"testing"
 )
 
@@ -25,55 +26,61 @@ var (
 )
 
 func TestPodnapisi(t *testing.T) {
-	wgs.Add(1)
-	go podnapisi(tName, tYear, tRelease, tLanguage, tCategory, 0, 0)
-	wgs.Wait()
+	c := newCall(context.Background())
 
-	if len(subtitles) == 0 {
+	c.wgs.Add(1)
+	go c.podnapisi(tName, tYear, tRelease, tLanguage, tCategory, 0, 0)
+	c.wgs.Wait()
+
+	if len(c.subtitles) == 0 {
 		t.Error("no results")
 	}
 
-	wgs.Add(1)
-	go podnapisi(teName, teYear, teRelease, teLanguage, teCategory, te_season, te_episode)
-	wgs.Wait()
+	c.wgs.Add(1)
+	go c.podnapisi(teName, teYear, teRelease, teLanguage, teCategory, te_season, te_episode)
+	c.wgs.Wait()
 
-	if len(subtitles) == 0 {
+	if len(c.subtitles) == 0 {
 		t.Error("no results")
 	}
 }
 
 func TestOpensubtitles(t *testing.T) {
-	wgs.Add(1)
-	go opensubtitles(tName, tImdbId, tYear, tRelease, tLanguage, tCategory, 0, 0)
-	wgs.Wait()
+	c := newCall(context.Background())
+
+	c.wgs.Add(1)
+	go c.opensubtitles(tName, tImdbId, tYear, tRelease, tLanguage, tCategory, 0, 0)
+	c.wgs.Wait()
 
-	if len(subtitles) == 0 {
+	if len(c.subtitles) == 0 {
 		t.Error("no results")
 	}
 
-	wgs.Add(1)
-	go opensubtitles(teName, teImdbId, teYear, teRelease, teLanguage, teCategory, te_season, te_episode)
-	wgs.Wait()
+	c.wgs.Add(1)
+	go c.opensubtitles(teName, teImdbId, teYear, teRelease, teLanguage, teCategory, te_season, te_episode)
+	c.wgs.Wait()
 
-	if len(subtitles) == 0 {
+	if len(c.subtitles) == 0 {
 		t.Error("no results")
 	}
 }
 
 func TestSubscene(t *testing.T) {
-	wgs.Add(1)
-	go subscene(tName, tYear, tRelease, tLanguage, tCategory, 0, 0)
-	wgs.Wait()
+	c := newCall(context.Background())
+
+	c.wgs.Add(1)
+	go c.subscene(tName, tYear, tRelease, tLanguage, tCategory, 0, 0)
+	c.wgs.Wait()
 
-	if len(subtitles) == 0 {
+	if len(c.subtitles) == 0 {
 		t.Error("no results")
 	}
 
-	wgs.Add(1)
-	go subscene(teName, teYear, teRelease, teLanguage, teCategory, te_season, te_episode)
-	wgs.Wait()
+	c.wgs.Add(1)
+	go c.subscene(teName, teYear, teRelease, teLanguage, teCategory, te_season, te_episode)
+	c.wgs.Wait()
 
-	if len(subtitles) == 0 {
+	if len(c.subtitles) == 0 {
 		t.Error("no results")
 	}
 }
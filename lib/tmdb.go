@@ -1,16 +1,33 @@
 package bukanir
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/url"
+	"regexp"
 	"strings"
+	"sync"
+	"time"
 )
 
+// reApiKey matches api_key parameter of uri
+var reApiKey = regexp.MustCompile(`([?&])api_key=[^&]*&?`)
+
+// reLanguage matches language parameter of uri, but not i.e. with_original_language
+var reLanguage = regexp.MustCompile(`[?&]language=`)
+
 // tmdb type
 type tmdb struct {
-	Api_key string
+	Api_key  string
+	Language string
+	Region   string
+	token    string
+	ctx      context.Context
+
+	// stale is set when stored metadata was used because request failed
+	stale bool
 }
 
 // tmdbConfig type
@@ -28,18 +45,33 @@ type tmdbResponse struct {
 
 // tmdbResult type
 type tmdbResult struct {
-	Adult          bool
-	Name           string
-	Backdrop_path  string
-	Id             int
-	Original_name  string
-	Original_title string
-	First_air_date string
-	Release_date   string
-	Poster_path    string
-	Title          string
-	Media_type     string
-	Profile_path   string
+	Adult             bool
+	Name              string
+	Backdrop_path     string
+	Id                int
+	Original_name     string
+	Original_title    string
+	Original_language string
+	First_air_date    string
+	Release_date      string
+	Poster_path       string
+	Title             string
+	Media_type        string
+	Profile_path      string
+	Popularity        float64
+	Overview          string
+}
+
+// tmdbAltTitles type, movies return titles and tv shows results
+type tmdbAltTitles struct {
+	Titles  []tmdbAltTitle
+	Results []tmdbAltTitle
+}
+
+// tmdbAltTitle type
+type tmdbAltTitle struct {
+	Iso_3166_1 string
+	Title      string
 }
 
 // tmdbImageConfig type
@@ -67,10 +99,80 @@ type tmdbMovie struct {
 	Imdb_id       string
 	Overview      string
 	Title         string
+	Name          string
+	Original_name string
+	Seasons       []tmdbSeason
 	Release_date  string
 	Tagline       string
 	Runtime       int
 	Vote_average  float64
+
+	Belongs_to_collection *tmdbCollection
+
+	Adult         bool
+	Release_dates tmdbReleaseDates
+
+	First_air_date       string
+	Status               string
+	Original_language    string
+	Spoken_languages     []tmdbLanguage
+	Production_countries []tmdbCountry
+	Origin_country       []string
+	Content_ratings      tmdbContentRatings
+}
+
+// tmdbLanguage type
+type tmdbLanguage struct {
+	Iso_639_1    string
+	English_name string
+	Name         string
+}
+
+// tmdbCountry type
+type tmdbCountry struct {
+	Iso_3166_1 string
+	Name       string
+}
+
+// tmdbReleaseDates type
+type tmdbReleaseDates struct {
+	Results []struct {
+		Iso_3166_1    string
+		Release_dates []struct {
+			Certification string
+			Type          int
+		}
+	}
+}
+
+// tmdbContentRatings type
+type tmdbContentRatings struct {
+	Results []struct {
+		Iso_3166_1 string
+		Rating     string
+	}
+}
+
+// tmdbCertifications type
+type tmdbCertifications struct {
+	Certifications map[string][]tmdbCertification
+}
+
+// tmdbCertification type
+type tmdbCertification struct {
+	Certification string
+	Meaning       string
+	Order         int
+}
+
+// tmdbCollection type
+type tmdbCollection struct {
+	Id            int
+	Name          string
+	Overview      string
+	Poster_path   string
+	Backdrop_path string
+	Parts         []tmdbResult
 }
 
 // tmdbEpisode type
@@ -78,10 +180,64 @@ type tmdbEpisode struct {
 	Air_date       string
 	Season_number  int
 	Episode_number int
+	Name           string
 	Overview       string
+	Still_path     string
 	Crew           []tmdbCrew
 }
 
+// tmdbPeople type
+type tmdbPeople struct {
+	Page          int
+	Results       []tmdbPerson
+	Total_pages   int
+	Total_results int
+}
+
+// tmdbPerson type
+type tmdbPerson struct {
+	Id                   int
+	Name                 string
+	Known_for_department string
+	Profile_path         string
+	Biography            string
+	Birthday             string
+	Deathday             string
+	Place_of_birth       string
+	Known_for            []tmdbResult
+	Combined_credits     tmdbCombinedCredits
+}
+
+// tmdbCombinedCredits type
+type tmdbCombinedCredits struct {
+	Cast []tmdbCredit
+	Crew []tmdbCredit
+}
+
+// tmdbCredit type
+type tmdbCredit struct {
+	Id             int
+	Title          string
+	Name           string
+	Release_date   string
+	First_air_date string
+	Media_type     string
+	Character      string
+	Job            string
+	Poster_path    string
+	Popularity     float64
+}
+
+// tmdbSeason type
+type tmdbSeason struct {
+	Air_date      string
+	Season_number int
+	Episode_count int
+	Name          string
+	Overview      string
+	Poster_path   string
+}
+
 // tmdbCredits type
 type tmdbCredits struct {
 	Id   int
@@ -141,13 +297,15 @@ type tmdbExternals struct {
 
 // tmdbVideo type
 type tmdbVideo struct {
-	Id        string
-	Iso_639_1 string
-	Key       string
-	Name      string
-	Site      string
-	Size      int
-	Type      string
+	Id         string
+	Iso_639_1  string
+	Iso_3166_1 string
+	Key        string
+	Name       string
+	Site       string
+	Size       int
+	Type       string
+	Official   bool
 }
 
 // poster type
@@ -162,9 +320,157 @@ type poster struct {
 	Width        int
 }
 
-// NewTmdb returns new tmdb
-func NewTmdb(api_key string) *tmdb {
-	return &tmdb{Api_key: api_key}
+// Memoized TMDB responses, shared by all calls
+var tmdbMemo = newLRU(1024)
+
+// Expiry of memoized responses
+const (
+	tmdbConfigTTL = 24 * time.Hour
+	tmdbSearchTTL = time.Hour
+)
+
+// Metadata language and region, empty language is english
+var (
+	localeMu     sync.RWMutex
+	metaLanguage string
+	metaRegion   string
+)
+
+// SetMetadataLanguage sets ISO 639-1 language and ISO 3166-1 region of TMDB metadata, i.e. "de" and "DE".
+// Empty language sets english, region is optional.
+func SetMetadataLanguage(language string, region string) {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+
+	metaLanguage = strings.ToLower(language)
+	metaRegion = strings.ToUpper(region)
+}
+
+// localeKey returns cache key of metadata language and region, empty for english
+func localeKey() string {
+	localeMu.RLock()
+	defer localeMu.RUnlock()
+
+	if metaLanguage == "" && metaRegion == "" {
+		return ""
+	}
+
+	return metaLanguage + "-" + metaRegion
+}
+
+// NewTmdb returns new tmdb, with api key from credentials
+func NewTmdb(ctx context.Context) *tmdb {
+	localeMu.RLock()
+	defer localeMu.RUnlock()
+
+	c := getCredentials()
+	return &tmdb{Api_key: c.TmdbApiKey, Language: metaLanguage, Region: metaRegion, token: c.TmdbToken, ctx: ctx}
+}
+
+// english returns tmdb without language, used as fallback for missing translations
+func (t *tmdb) english() *tmdb {
+	return &tmdb{Api_key: t.Api_key, token: t.token, ctx: t.ctx}
+}
+
+// localized checks if tmdb uses language other than english
+func (t *tmdb) localized() bool {
+	return t.Language != "" && t.Language != "en"
+}
+
+// prepare returns localized uri, api key is removed when it is empty or requests are authorized with bearer token
+func (t *tmdb) prepare(uri string) string {
+	uri = t.localize(uri)
+
+	if t.Api_key == "" || t.token != "" {
+		uri = reApiKey.ReplaceAllString(uri, "$1")
+		uri = strings.TrimRight(uri, "?&")
+	}
+
+	return uri
+}
+
+// localize adds language, region and image language parameters to uri, uris with language are not changed
+func (t *tmdb) localize(uri string) string {
+	if !t.localized() || reLanguage.MatchString(uri) {
+		return uri
+	}
+
+	language := t.Language
+	if t.Region != "" {
+		language += "-" + t.Region
+		uri += "&region=" + t.Region
+	}
+
+	include := url.QueryEscape(t.Language + ",en,null")
+	return uri + "&language=" + language + "&include_image_language=" + include + "&include_video_language=" + include
+}
+
+// getMemo returns memoized response body, or gets it and memoizes it for ttl.
+// Response is also kept in metadata store if kind is not empty.
+func (t *tmdb) getMemo(kind string, uri string, ttl time.Duration) ([]byte, error) {
+	uri = t.prepare(uri)
+
+	if body, ok := tmdbMemo.Get(uri); ok {
+		return body, nil
+	}
+
+	body, stale, err := t.fetch(kind, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if stale {
+		t.stale = true
+	} else {
+		tmdbMemo.Add(uri, body, ttl)
+	}
+
+	return body, nil
+}
+
+// getStored returns response body, kept in metadata store if kind is not empty
+func (t *tmdb) getStored(kind string, uri string) ([]byte, error) {
+	body, stale, err := t.fetch(kind, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	t.stale = t.stale || stale
+	return body, nil
+}
+
+// fetch returns fresh stored body, or gets it and saves it to metadata store.
+// When request fails, stale stored body is returned.
+func (t *tmdb) fetch(kind string, uri string) ([]byte, bool, error) {
+	uri = t.prepare(uri)
+
+	if kind == "" {
+		body, err := getBody(t.ctx, uri)
+		return body, false, err
+	}
+
+	key := redact(uri)
+
+	stored, fresh, ok := storeGet(kind, key)
+	if ok && fresh {
+		return stored, false, nil
+	}
+
+	body, err := getBody(t.ctx, uri)
+	if err != nil {
+		if ok && !isCanceled(err) {
+			if verbose {
+				log.Printf("TMDB: Using stale %s: %s\n", kind, err.Error())
+			}
+
+			return stored, true, nil
+		}
+
+		return nil, false, err
+	}
+
+	storePut(kind, key, body)
+	return body, false, nil
 }
 
 // GetConfig returns new tmdb config
@@ -176,7 +482,7 @@ func (t *tmdb) GetConfig() (*tmdbConfig, error) {
 		log.Printf("TMDB: GET %s\n", t.safeUri(uri, false))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getMemo("config", uri, tmdbConfigTTL)
 	if err != nil {
 		return conf, err
 	}
@@ -195,7 +501,7 @@ func (t *tmdb) SearchMovie(query string) (tmdbResponse, error) {
 		//log.Printf("TMDB: GET %s\n", t.safeUri(uri, true))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getMemo("", uri, tmdbSearchTTL)
 	if err != nil {
 		return resp, err
 	}
@@ -214,7 +520,7 @@ func (t *tmdb) SearchTv(query string) (tmdbResponse, error) {
 		//log.Printf("TMDB: GET %s\n", t.safeUri(uri, true))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getMemo("", uri, tmdbSearchTTL)
 	if err != nil {
 		return resp, err
 	}
@@ -233,7 +539,7 @@ func (t *tmdb) AutoCompleteMovie(query string) (tmdbResponse, error) {
 		//log.Printf("TMDB: GET %s\n", t.safeUri(uri, true))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getStored("", uri)
 	if err != nil {
 		return resp, err
 	}
@@ -252,7 +558,7 @@ func (t *tmdb) AutoCompleteTv(query string) (tmdbResponse, error) {
 		//log.Printf("TMDB: GET %s\n", t.safeUri(uri, true))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getStored("", uri)
 	if err != nil {
 		return resp, err
 	}
@@ -265,19 +571,27 @@ func (t *tmdb) AutoCompleteTv(query string) (tmdbResponse, error) {
 // GetMovieDetails returns movie details
 func (t *tmdb) GetMovieDetails(id string) (tmdbMovie, error) {
 	var movie tmdbMovie
-	uri := fmt.Sprintf("%s/movie/%s?api_key=%s&append_to_response=credits,videos", tmdbApiUrl, id, t.Api_key)
+	uri := fmt.Sprintf("%s/movie/%s?api_key=%s&append_to_response=credits,videos,release_dates", tmdbApiUrl, id, t.Api_key)
 
 	if verbose {
 		log.Printf("TMDB: GET %s\n", t.safeUri(uri, true))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getStored("movie", uri)
 	if err != nil {
 		return movie, err
 	}
 	if err := json.Unmarshal(body, &movie); err != nil {
 		return movie, err
 	}
+
+	if t.localized() && (movie.Overview == "" || movie.Tagline == "") {
+		en, err := t.english().GetMovieDetails(id)
+		if err == nil {
+			fallback(&movie, en)
+		}
+	}
+
 	return movie, nil
 }
 
@@ -285,26 +599,78 @@ func (t *tmdb) GetMovieDetails(id string) (tmdbMovie, error) {
 func (t *tmdb) GetTvDetails(id string, season int) (tmdbMovie, error) {
 	var movie tmdbMovie
 	var uri string
+	kind := "tv"
 	if season > 0 {
+		kind = "season"
 		uri = fmt.Sprintf("%s/tv/%s/season/%d?api_key=%s&append_to_response=credits,videos", tmdbApiUrl, id, season, t.Api_key)
 	} else {
-		uri = fmt.Sprintf("%s/tv/%s?api_key=%s&append_to_response=credits,videos", tmdbApiUrl, id, t.Api_key)
+		uri = fmt.Sprintf("%s/tv/%s?api_key=%s&append_to_response=credits,videos,content_ratings", tmdbApiUrl, id, t.Api_key)
 	}
 
 	if verbose {
 		log.Printf("TMDB: GET %s\n", t.safeUri(uri, true))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getStored(kind, uri)
 	if err != nil {
 		return movie, err
 	}
 	if err := json.Unmarshal(body, &movie); err != nil {
 		return movie, err
 	}
+
+	if t.localized() && (movie.Overview == "" || movie.Tagline == "" || untranslated(movie.Episodes)) {
+		en, err := t.english().GetTvDetails(id, season)
+		if err == nil {
+			fallback(&movie, en)
+		}
+	}
+
 	return movie, nil
 }
 
+// GetCollection returns movie collection with its parts
+func (t *tmdb) GetCollection(id string) (tmdbCollection, error) {
+	var collection tmdbCollection
+	uri := fmt.Sprintf("%s/collection/%s?api_key=%s", tmdbApiUrl, id, t.Api_key)
+
+	if verbose {
+		log.Printf("TMDB: GET %s\n", t.safeUri(uri, false))
+	}
+
+	body, err := t.getStored("movie", uri)
+	if err != nil {
+		return collection, err
+	}
+	if err := json.Unmarshal(body, &collection); err != nil {
+		return collection, err
+	}
+	return collection, nil
+}
+
+// Certifications returns movie or tv certifications by country
+func (t *tmdb) Certifications(tv bool) (map[string][]tmdbCertification, error) {
+	var resp tmdbCertifications
+	media := "movie"
+	if tv {
+		media = "tv"
+	}
+	uri := fmt.Sprintf("%s/certification/%s/list?api_key=%s", tmdbApiUrl, media, t.Api_key)
+
+	if verbose {
+		log.Printf("TMDB: GET %s\n", t.safeUri(uri, false))
+	}
+
+	body, err := t.getStored("certification", uri)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Certifications, nil
+}
+
 // GetTvImages returns tv show images
 func (t *tmdb) GetTvImages(id string, season int) (tmdbPoster, error) {
 	var poster tmdbPoster
@@ -314,7 +680,7 @@ func (t *tmdb) GetTvImages(id string, season int) (tmdbPoster, error) {
 		//log.Printf("TMDB: GET %s\n", t.safeUri(uri, false))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getMemo("images", uri, tmdbSearchTTL)
 	if err != nil {
 		return poster, err
 	}
@@ -324,6 +690,72 @@ func (t *tmdb) GetTvImages(id string, season int) (tmdbPoster, error) {
 	return poster, nil
 }
 
+// SearchPerson returns person search response
+func (t *tmdb) SearchPerson(query string) (tmdbPeople, error) {
+	var resp tmdbPeople
+	uri := fmt.Sprintf("%s/search/person?api_key=%s&query=%s", tmdbApiUrl, t.Api_key, url.QueryEscape(query))
+
+	if verbose {
+		//log.Printf("TMDB: GET %s\n", t.safeUri(uri, true))
+	}
+
+	body, err := t.getMemo("", uri, tmdbSearchTTL)
+	if err != nil {
+		return resp, err
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// GetPersonDetails returns person details with combined credits
+func (t *tmdb) GetPersonDetails(id string) (tmdbPerson, error) {
+	var person tmdbPerson
+	uri := fmt.Sprintf("%s/person/%s?api_key=%s&append_to_response=combined_credits", tmdbApiUrl, id, t.Api_key)
+
+	if verbose {
+		log.Printf("TMDB: GET %s\n", t.safeUri(uri, true))
+	}
+
+	body, err := t.getStored("person", uri)
+	if err != nil {
+		return person, err
+	}
+	if err := json.Unmarshal(body, &person); err != nil {
+		return person, err
+	}
+	return person, nil
+}
+
+// GetAlternativeTitles returns alternative titles of movie or tv show
+func (t *tmdb) GetAlternativeTitles(id string, tv bool) ([]string, error) {
+	var alt tmdbAltTitles
+	media := "movie"
+	if tv {
+		media = "tv"
+	}
+	uri := fmt.Sprintf("%s/%s/%s/alternative_titles?api_key=%s", tmdbApiUrl, media, id, t.Api_key)
+
+	if verbose {
+		log.Printf("TMDB: GET %s\n", t.safeUri(uri, false))
+	}
+
+	body, err := t.getStored("alternative", uri)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &alt); err != nil {
+		return nil, err
+	}
+
+	titles := make([]string, 0)
+	for _, a := range append(alt.Titles, alt.Results...) {
+		titles = append(titles, a.Title)
+	}
+	return titles, nil
+}
+
 // GetTvExternals returns tv show externals
 func (t *tmdb) GetTvExternals(id string) (tmdbExternals, error) {
 	var ext tmdbExternals
@@ -333,7 +765,7 @@ func (t *tmdb) GetTvExternals(id string) (tmdbExternals, error) {
 		log.Printf("TMDB: GET %s\n", t.safeUri(uri, false))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getStored("externals", uri)
 	if err != nil {
 		return ext, err
 	}
@@ -352,7 +784,7 @@ func (t *tmdb) PopularMovies() (tmdbResponse, error) {
 		log.Printf("TMDB: GET %s\n", t.safeUri(uri, false))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getStored("list", uri)
 	if err != nil {
 		return resp, err
 	}
@@ -371,7 +803,7 @@ func (t *tmdb) PopularTv() (tmdbResponse, error) {
 		log.Printf("TMDB: GET %s\n", t.safeUri(uri, false))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getStored("list", uri)
 	if err != nil {
 		return resp, err
 	}
@@ -390,7 +822,7 @@ func (t *tmdb) TopRatedMovies() (tmdbResponse, error) {
 		log.Printf("TMDB: GET %s\n", t.safeUri(uri, false))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getStored("list", uri)
 	if err != nil {
 		return resp, err
 	}
@@ -409,7 +841,7 @@ func (t *tmdb) TopRatedTv() (tmdbResponse, error) {
 		log.Printf("TMDB: GET %s\n", t.safeUri(uri, false))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getStored("list", uri)
 	if err != nil {
 		return resp, err
 	}
@@ -428,7 +860,7 @@ func (t *tmdb) Genres() (tmdbGenres, error) {
 		log.Printf("TMDB: GET %s\n", t.safeUri(uri, false))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getStored("genres", uri)
 	if err != nil {
 		return resp, err
 	}
@@ -447,7 +879,68 @@ func (t *tmdb) Genre(id, page int) (tmdbResponse, error) {
 		log.Printf("TMDB: GET %s\n", t.safeUri(uri, true))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getStored("", uri)
+	if err != nil {
+		return resp, err
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// Related returns similar or recommended movies or tv shows response
+func (t *tmdb) Related(kind string, id int, tv bool, page int) (tmdbResponse, error) {
+	var resp tmdbResponse
+	media := "movie"
+	if tv {
+		media = "tv"
+	}
+	uri := fmt.Sprintf("%s/%s/%d/%s?api_key=%s&page=%d", tmdbApiUrl, media, id, kind, t.Api_key, page)
+
+	if verbose {
+		log.Printf("TMDB: GET %s\n", t.safeUri(uri, true))
+	}
+
+	body, err := t.getStored("list", uri)
+	if err != nil {
+		return resp, err
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// Discover returns discover response for movie or tv media
+func (t *tmdb) Discover(media string, query url.Values, page int) (tmdbResponse, error) {
+	var resp tmdbResponse
+	uri := fmt.Sprintf("%s/discover/%s?api_key=%s&%s&page=%d", tmdbApiUrl, media, t.Api_key, query.Encode(), page)
+
+	if verbose {
+		log.Printf("TMDB: GET %s\n", t.safeUri(uri, true))
+	}
+
+	body, err := t.getStored("list", uri)
+	if err != nil {
+		return resp, err
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// List returns response of TMDB list path, e.g. movie/now_playing
+func (t *tmdb) List(path string, page int) (tmdbResponse, error) {
+	var resp tmdbResponse
+	uri := fmt.Sprintf("%s/%s?api_key=%s&page=%d", tmdbApiUrl, path, t.Api_key, page)
+
+	if verbose {
+		log.Printf("TMDB: GET %s\n", t.safeUri(uri, true))
+	}
+
+	body, err := t.getStored("list", uri)
 	if err != nil {
 		return resp, err
 	}
@@ -466,7 +959,7 @@ func (t *tmdb) MoviesWithCast(id, page int) (tmdbResponse, error) {
 		log.Printf("TMDB: GET %s\n", t.safeUri(uri, true))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getStored("", uri)
 	if err != nil {
 		return resp, err
 	}
@@ -485,7 +978,7 @@ func (t *tmdb) MoviesWithCrew(id, page int) (tmdbResponse, error) {
 		log.Printf("TMDB: GET %s\n", t.safeUri(uri, true))
 	}
 
-	body, err := getBody(uri)
+	body, err := t.getStored("", uri)
 	if err != nil {
 		return resp, err
 	}
@@ -495,6 +988,34 @@ func (t *tmdb) MoviesWithCrew(id, page int) (tmdbResponse, error) {
 	return resp, nil
 }
 
+// fallback fills missing translations from english details
+func fallback(movie *tmdbMovie, en tmdbMovie) {
+	if movie.Overview == "" {
+		movie.Overview = en.Overview
+	}
+
+	if movie.Tagline == "" {
+		movie.Tagline = en.Tagline
+	}
+
+	for n := range movie.Episodes {
+		if movie.Episodes[n].Overview == "" && n < len(en.Episodes) {
+			movie.Episodes[n].Overview = en.Episodes[n].Overview
+		}
+	}
+}
+
+// untranslated checks if any episode is missing overview
+func untranslated(episodes []tmdbEpisode) bool {
+	for _, e := range episodes {
+		if e.Overview == "" {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (t *tmdb) safeUri(uri string, b bool) string {
 	if b {
 		return strings.Replace(uri, fmt.Sprintf("?api_key=%s&", t.Api_key), "?", -1)
@@ -1,12 +1,14 @@
 package bukanir
 
 import (
+	"context"
 	"strconv"
+	"strings"
 	"testing"
 )
 
 func TestSearchMovie(t *testing.T) {
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(context.Background())
 
 	results, err := md.SearchMovie(tName)
 	if err != nil {
@@ -19,7 +21,7 @@ func TestSearchMovie(t *testing.T) {
 }
 
 func TestSearchTv(t *testing.T) {
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(context.Background())
 
 	results, err := md.SearchTv(teName)
 	if err != nil {
@@ -32,7 +34,7 @@ func TestSearchTv(t *testing.T) {
 }
 
 func TestMovieDetails(t *testing.T) {
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(context.Background())
 
 	results, err := md.GetMovieDetails(strconv.Itoa(tId))
 	if err != nil {
@@ -45,7 +47,7 @@ func TestMovieDetails(t *testing.T) {
 }
 
 func TestTvDetails(t *testing.T) {
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(context.Background())
 
 	results, err := md.GetTvDetails(strconv.Itoa(teId), -1)
 	if err != nil {
@@ -58,7 +60,7 @@ func TestTvDetails(t *testing.T) {
 }
 
 func TestPopular(t *testing.T) {
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(context.Background())
 
 	results, err := md.PopularMovies()
 	if err != nil {
@@ -71,7 +73,7 @@ func TestPopular(t *testing.T) {
 }
 
 func TestTopRated(t *testing.T) {
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(context.Background())
 
 	results, err := md.TopRatedMovies()
 	if err != nil {
@@ -84,7 +86,7 @@ func TestTopRated(t *testing.T) {
 }
 
 func TestGenres(t *testing.T) {
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(context.Background())
 
 	results, err := md.Genres()
 	if err != nil {
@@ -97,7 +99,7 @@ func TestGenres(t *testing.T) {
 }
 
 func TestGenre(t *testing.T) {
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(context.Background())
 
 	results, err := md.Genre(16, 1)
 	if err != nil {
@@ -110,7 +112,7 @@ func TestGenre(t *testing.T) {
 }
 
 func TestCast(t *testing.T) {
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(context.Background())
 
 	results, err := md.MoviesWithCast(85, 1)
 	if err != nil {
@@ -123,7 +125,7 @@ func TestCast(t *testing.T) {
 }
 
 func TestCrew(t *testing.T) {
-	md := NewTmdb(tmdbApiKey)
+	md := NewTmdb(context.Background())
 
 	results, err := md.MoviesWithCrew(578, 1)
 	if err != nil {
@@ -134,3 +136,31 @@ func TestCrew(t *testing.T) {
 		t.Error("no results")
 	}
 }
+
+func TestLocalize(t *testing.T) {
+	SetMetadataLanguage("de", "at")
+	defer SetMetadataLanguage("", "")
+
+	md := NewTmdb(context.Background())
+
+	uri := md.localize(tmdbApiUrl + "/movie/550?api_key=" + md.Api_key)
+	want := tmdbApiUrl + "/movie/550?api_key=" + md.Api_key + "&region=AT&language=de-AT&include_image_language=de%2Cen%2Cnull&include_video_language=de%2Cen%2Cnull"
+	if uri != want {
+		t.Errorf("got %s, want %s", uri, want)
+	}
+
+	if md.english().localize(want) != want || md.localize(want) != want {
+		t.Error("uri should not be localized twice")
+	}
+
+	uri = md.localize(tmdbApiUrl + "/discover/movie?api_key=" + md.Api_key + "&with_original_language=fr")
+	if !strings.Contains(uri, "&language=de-AT") {
+		t.Errorf("discover uri with original language is not localized: %s", uri)
+	}
+
+	movie := tmdbMovie{Tagline: "Tagline", Episodes: []tmdbEpisode{{Overview: ""}}}
+	fallback(&movie, tmdbMovie{Overview: "Overview", Tagline: "English", Episodes: []tmdbEpisode{{Overview: "Episode"}}})
+	if movie.Overview != "Overview" || movie.Tagline != "Tagline" || movie.Episodes[0].Overview != "Episode" {
+		t.Errorf("unexpected fallback %+v", movie)
+	}
+}
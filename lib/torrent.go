@@ -459,6 +459,8 @@ func (t *torrent) startHTTP() {
 		fmt.Fprintf(w, "OK")
 	})
 	mux.Handle("/files/", http.StripPrefix("/files/", http.FileServer(t.torrentFs)))
+	mux.Handle("/images/", imageHandler())
+	mux.Handle("/trailers/", trailerHandler())
 
 	handler := http.Handler(mux)
 
@@ -581,6 +583,15 @@ func (t *torrent) Running() bool {
 }
 
 func (t *torrent) Status() (string, error) {
+	js, err := json.MarshalIndent(t.status(), "", "    ")
+	if err != nil {
+		return "empty", err
+	}
+
+	return string(js[:]), nil
+}
+
+func (t *torrent) status() SessionStatus {
 	var status SessionStatus
 	if t.torrent == nil {
 		status = SessionStatus{State: -1}
@@ -602,7 +613,11 @@ func (t *torrent) Status() (string, error) {
 			TotalSeeds:    tstatus.GetNumComplete()}
 	}
 
-	js, err := json.MarshalIndent(status, "", "    ")
+	return status
+}
+
+func (t *torrent) Ls() (string, error) {
+	js, err := json.MarshalIndent(t.ls(), "", "    ")
 	if err != nil {
 		return "empty", err
 	}
@@ -610,7 +625,7 @@ func (t *torrent) Status() (string, error) {
 	return string(js[:]), nil
 }
 
-func (t *torrent) Ls() (string, error) {
+func (t *torrent) ls() LsInfo {
 	retFiles := LsInfo{}
 
 	if t.torrentFs.HasTorrentInfo() {
@@ -633,10 +648,5 @@ func (t *torrent) Ls() (string, error) {
 		}
 	}
 
-	js, err := json.MarshalIndent(retFiles, "", "    ")
-	if err != nil {
-		return "empty", err
-	}
-
-	return string(js[:]), nil
+	return retFiles
 }
@@ -1,6 +1,7 @@
 package bukanir
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -13,22 +14,24 @@ import (
 // tpb type
 type tpb struct {
 	Host string
+	ctx  context.Context
 }
 
 // tpbTorrent type
 type tpbTorrent struct {
-	ID       json.Number `json:"id",type:"integer"`
+	ID       json.Number `json:"id"`
 	InfoHash string      `json:"info_hash"`
-	Category json.Number `json:"category",type:"integer"`
+	Category json.Number `json:"category"`
 	Name     string      `json:"name"`
-	Size     json.Number `json:"size",type:"integer"`
-	Seeders  json.Number `json:"seeders",type:"integer"`
-	Leechers json.Number `json:"leechers",type:"integer"`
+	Size     json.Number `json:"size"`
+	Seeders  json.Number `json:"seeders"`
+	Leechers json.Number `json:"leechers"`
+	Added    json.Number `json:"added"`
 }
 
 // NewTpb returns new tpb
-func NewTpb(host string) *tpb {
-	return &tpb{Host: host}
+func NewTpb(ctx context.Context, host string) *tpb {
+	return &tpb{Host: host, ctx: ctx}
 }
 
 // Top returns top torrents for category
@@ -40,7 +43,7 @@ func (t *tpb) Top(category int) ([]TTorrent, error) {
 		log.Printf("TPB: GET %s\n", uri)
 	}
 
-	body, err := getBody(uri)
+	body, err := getBody(t.ctx, uri)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +65,7 @@ func (t *tpb) Search(query string, page int, cats string) ([]TTorrent, error) {
 		log.Printf("TPB: GET %s\n", uri)
 	}
 
-	body, err := getBody(uri)
+	body, err := getBody(t.ctx, uri)
 	if err != nil {
 		return nil, err
 	}
@@ -88,20 +91,14 @@ func (t *tpb) getTorrents(body []byte) ([]TTorrent, error) {
 	for _, torrent := range torrents {
 		category, _ := torrent.Category.Int64()
 		seeders, _ := torrent.Seeders.Int64()
+		leechers, _ := torrent.Leechers.Int64()
+		added, _ := torrent.Added.Int64()
 		size, _ := torrent.Size.Int64()
 
 		if getTitle(torrent.Name) == "" {
 			continue
 		}
 
-		if seeders == 0 {
-			continue
-		}
-
-		if size > 5120*1024*1024 {
-			continue
-		}
-
 		season, _ := strconv.Atoi(getSeason(torrent.Name))
 		episode, _ := strconv.Atoi(getEpisode(torrent.Name))
 		magnet := fmt.Sprintf("magnet:?xt=urn:btih:%s&dn=%s%s", torrent.InfoHash, url.QueryEscape(torrent.Name), getTrackers())
@@ -123,6 +120,9 @@ func (t *tpb) getTorrents(body []byte) ([]TTorrent, error) {
 			int(category),
 			season,
 			episode,
+			"tpb",
+			int(leechers),
+			added,
 		}
 
 		results = append(results, t)
@@ -1,11 +1,12 @@
 package bukanir
 
 import (
+	"context"
 	"testing"
 )
 
 func TestTopTpb(t *testing.T) {
-	pb := NewTpb(getTpbHost())
+	pb := NewTpb(context.Background(), getTpbHost())
 
 	results, err := pb.Top(CategoryMovies)
 	if err != nil {
@@ -18,7 +19,7 @@ func TestTopTpb(t *testing.T) {
 }
 
 func TestSearchTpb(t *testing.T) {
-	pb := NewTpb(getTpbHost())
+	pb := NewTpb(context.Background(), getTpbHost())
 
 	results, err := pb.Search(tName, 0, "201,207,205,208")
 	if err != nil {
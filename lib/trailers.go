@@ -0,0 +1,348 @@
+package bukanir
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kkdai/youtube"
+)
+
+// TMDB video types in order of preference, other types are last
+var videoTypes = []string{"Trailer", "Teaser", "Clip", "Featurette", "Behind the Scenes", "Bloopers"}
+
+// Default trailer quality
+const trailerQuality = "360p"
+
+// Time after which YouTube stream urls expire
+const trailerTTL = 5 * time.Hour
+
+// Trailer streams served by local HTTP server, keyed by video id and quality, and trailer cache directory and quota
+var (
+	trailersMu    sync.Mutex
+	trailersDir   string
+	trailersQuota int64
+	trailersSize  int64 = -1
+	trailers            = make(map[string]trailerStream)
+)
+
+var reTrailer = regexp.MustCompile(`^[A-Za-z0-9_\-]+/[0-9]+p$`)
+
+// trailerStream type
+type trailerStream struct {
+	url     string
+	cache   bool
+	expires time.Time
+}
+
+// SetTrailerCache sets directory of cached trailers and quota in megabytes, zero quota is unlimited.
+// Trailers are cached in temporary directory when directory is not set.
+func SetTrailerCache(dir string, quota int) {
+	trailersMu.Lock()
+	defer trailersMu.Unlock()
+
+	trailersDir = dir
+	trailersQuota = int64(quota) * 1024 * 1024
+	trailersSize = -1
+}
+
+// Trailers returns TMDB videos of movie or tv show, trailers in metadata language are first
+func (c *Client) Trailers(ctx context.Context, id int, category int) ([]TVideo, error) {
+	videos := make([]TVideo, 0)
+	md := NewTmdb(ctx)
+
+	var res tmdbMovie
+	var err error
+	if category == CategoryTV || category == CategoryHDTV {
+		res, err = md.GetTvDetails(strconv.Itoa(id), -1)
+	} else {
+		res, err = md.GetMovieDetails(strconv.Itoa(id))
+	}
+	if err != nil {
+		log.Printf("ERROR: Trailers %d: %s\n", id, err.Error())
+		return videos, metadataError(err)
+	}
+
+	for _, v := range sortVideos(res.Videos.Results, md.Language) {
+		videos = append(videos, TVideo{v.Key, v.Name, v.Type, v.Site, v.Iso_639_1, v.Iso_3166_1, v.Size, v.Official})
+	}
+
+	if len(videos) == 0 {
+		return videos, ErrNoResults
+	}
+
+	return videos, nil
+}
+
+// Trailer returns url of YouTube video stream in preferred quality, i.e. "720p". When quality is not available
+// the best lower quality is used, or the lowest higher quality. Proxied and cached streams are served by local HTTP server.
+func (c *Client) Trailer(ctx context.Context, videoId string, quality string, stream int) (string, error) {
+	if quality == "" {
+		quality = trailerQuality
+	}
+
+	key := videoId + "/" + quality
+	if !reTrailer.MatchString(key) {
+		return "", fmt.Errorf("invalid video %q or quality %q", videoId, quality)
+	}
+
+	imagesMu.Lock()
+	base := imagesUrl
+	imagesMu.Unlock()
+
+	if stream != TrailerDirect && base == "" {
+		log.Printf("ERROR: Trailer: local server is not started, using direct stream\n")
+		stream = TrailerDirect
+	}
+
+	if stream == TrailerCache {
+		if _, err := os.Stat(trailerFile(key)); err == nil {
+			return base + "/trailers/" + key, nil
+		}
+	}
+
+	client := youtube.Client{}
+
+	video, err := client.GetVideo(videoId)
+	if err != nil {
+		return "", err
+	}
+
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	formats := video.Formats.WithAudioChannels()
+	if len(formats) == 0 {
+		return "", fmt.Errorf("no formats found")
+	}
+
+	labels := make([]string, 0)
+	for _, f := range formats {
+		labels = append(labels, f.QualityLabel)
+	}
+
+	format := formats[pickQuality(labels, quality)]
+
+	if verbose {
+		log.Printf("BUK: Trailer %s quality %s\n", videoId, format.QualityLabel)
+	}
+
+	if stream == TrailerDirect {
+		return format.URL, nil
+	}
+
+	now := time.Now()
+
+	trailersMu.Lock()
+	for k, t := range trailers {
+		if now.After(t.expires) {
+			delete(trailers, k)
+		}
+	}
+	trailers[key] = trailerStream{format.URL, stream == TrailerCache, now.Add(trailerTTL)}
+	trailersMu.Unlock()
+
+	return base + "/trailers/" + key, nil
+}
+
+// pickQuality returns index of quality label that is the best match for quality, i.e. "720p"
+func pickQuality(labels []string, quality string) int {
+	want := qualityHeight(quality)
+
+	best, bestHeight := 0, qualityHeight(labels[0])
+	for n, l := range labels {
+		h := qualityHeight(l)
+		if h == want {
+			return n
+		}
+
+		// Prefer the best lower quality, then the lowest higher quality
+		if (h < want && (bestHeight > want || h > bestHeight)) || (h > want && bestHeight > want && h < bestHeight) {
+			best, bestHeight = n, h
+		}
+	}
+
+	return best
+}
+
+// qualityHeight returns video height from quality label, i.e. 720 for "720p60"
+func qualityHeight(label string) int {
+	n := strings.IndexFunc(label, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	if n == -1 {
+		n = len(label)
+	}
+
+	h, _ := strconv.Atoi(label[:n])
+	return h
+}
+
+// sortVideos returns videos ordered by type, language, official, site and size
+func sortVideos(res []tmdbVideo, language string) []tmdbVideo {
+	if language == "" {
+		language = "en"
+	}
+
+	typeOrder := func(v tmdbVideo) int {
+		for n, t := range videoTypes {
+			if strings.EqualFold(v.Type, t) {
+				return n
+			}
+		}
+		return len(videoTypes)
+	}
+
+	videos := append([]tmdbVideo{}, res...)
+	sort.SliceStable(videos, func(i, j int) bool {
+		a, b := videos[i], videos[j]
+		if typeOrder(a) != typeOrder(b) {
+			return typeOrder(a) < typeOrder(b)
+		}
+		if (a.Iso_639_1 == language) != (b.Iso_639_1 == language) {
+			return a.Iso_639_1 == language
+		}
+		if a.Official != b.Official {
+			return a.Official
+		}
+		if strings.EqualFold(a.Site, "youtube") != strings.EqualFold(b.Site, "youtube") {
+			return strings.EqualFold(a.Site, "youtube")
+		}
+		return a.Size > b.Size
+	})
+
+	return videos
+}
+
+// trailerDir returns trailer cache directory
+func trailerDir() string {
+	trailersMu.Lock()
+	dir := trailersDir
+	trailersMu.Unlock()
+
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "bukanir-trailers")
+	}
+
+	return dir
+}
+
+// trailerFile returns cache file of trailer
+func trailerFile(key string) string {
+	return filepath.Join(trailerDir(), strings.Replace(key, "/", "-", 1))
+}
+
+// trailerHandler serves trailers from /trailers/{videoId}/{quality}, cached trailers are saved while they are streamed
+func trailerHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/trailers/")
+		if !reTrailer.MatchString(key) {
+			http.NotFound(w, r)
+			return
+		}
+
+		file := trailerFile(key)
+		if _, err := os.Stat(file); err == nil {
+			now := time.Now()
+			os.Chtimes(file, now, now)
+			http.ServeFile(w, r, file)
+			return
+		}
+
+		trailersMu.Lock()
+		t, ok := trailers[key]
+		trailersMu.Unlock()
+
+		if !ok || time.Now().After(t.expires) {
+			http.NotFound(w, r)
+			return
+		}
+
+		req, err := http.NewRequest(r.Method, t.url, nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if rg := r.Header.Get("Range"); rg != "" {
+			req.Header.Set("Range", rg)
+		}
+
+		// Streams are long, so only connection and response headers have timeouts
+		client := &http.Client{
+			Transport: &http.Transport{
+				DialContext:           (&net.Dialer{Timeout: 30 * time.Second}).DialContext,
+				ResponseHeaderTimeout: 30 * time.Second,
+			},
+		}
+
+		res, err := client.Do(req.WithContext(r.Context()))
+		if err != nil {
+			log.Printf("ERROR: Trailer %s: %s\n", key, err.Error())
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer res.Body.Close()
+
+		for _, h := range []string{"Content-Type", "Content-Length", "Content-Range", "Accept-Ranges"} {
+			if v := res.Header.Get(h); v != "" {
+				w.Header().Set(h, v)
+			}
+		}
+		w.WriteHeader(res.StatusCode)
+
+		// Only complete streams are cached
+		complete := res.StatusCode == http.StatusOK || (res.StatusCode == http.StatusPartialContent && fullRange(res.Header.Get("Content-Range")))
+
+		var out io.Writer = w
+		var tmp *os.File
+		if t.cache && r.Method == "GET" && complete {
+			err = os.MkdirAll(filepath.Dir(file), 0777)
+			if err == nil {
+				tmp, err = ioutil.TempFile(filepath.Dir(file), "tmp")
+			}
+			if err != nil {
+				log.Printf("ERROR: Trailer cache %s: %s\n", key, err.Error())
+			} else {
+				out = io.MultiWriter(w, tmp)
+			}
+		}
+
+		n, err := io.Copy(out, res.Body)
+
+		if tmp != nil {
+			tmp.Close()
+			if err == nil {
+				err = os.Rename(tmp.Name(), file)
+			}
+			if err != nil {
+				os.Remove(tmp.Name())
+				return
+			}
+
+			trailersMu.Lock()
+			addCached(filepath.Dir(file), trailersQuota, &trailersSize, n)
+			trailersMu.Unlock()
+		}
+	})
+}
+
+// fullRange checks if content range covers whole content, i.e. "bytes 0-999/1000"
+func fullRange(cr string) bool {
+	var start, end, size int64
+	_, err := fmt.Sscanf(cr, "bytes %d-%d/%d", &start, &end, &size)
+	return err == nil && start == 0 && end == size-1
+}
@@ -0,0 +1,64 @@
+package bukanir
+
+import (
+	"testing"
+)
+
+func TestPickQuality(t *testing.T) {
+	tests := []struct {
+		labels  []string
+		quality string
+		want    int
+	}{
+		{[]string{"360p", "720p"}, "720p", 1},
+		{[]string{"360p", "480p", "1080p"}, "720p", 1},
+		{[]string{"1080p", "360p"}, "720p", 1},
+		{[]string{"1440p", "1080p"}, "720p", 1},
+		{[]string{"720p60", "360p"}, "720p", 0},
+		{[]string{"", "240p"}, "360p", 1},
+	}
+
+	for _, tt := range tests {
+		if got := pickQuality(tt.labels, tt.quality); got != tt.want {
+			t.Errorf("pickQuality(%v, %q) = %d, want %d", tt.labels, tt.quality, got, tt.want)
+		}
+	}
+}
+
+func TestSortVideos(t *testing.T) {
+	res := []tmdbVideo{
+		{Key: "clip", Type: "Clip", Site: "YouTube", Iso_639_1: "de"},
+		{Key: "teaser", Type: "Teaser", Site: "YouTube", Iso_639_1: "de"},
+		{Key: "en", Type: "Trailer", Site: "YouTube", Iso_639_1: "en", Official: true},
+		{Key: "vimeo", Type: "Trailer", Site: "Vimeo", Iso_639_1: "de", Official: true},
+		{Key: "unofficial", Type: "Trailer", Site: "YouTube", Iso_639_1: "de", Size: 1080},
+		{Key: "official", Type: "Trailer", Site: "YouTube", Iso_639_1: "de", Official: true, Size: 720},
+	}
+
+	want := []string{"official", "vimeo", "unofficial", "en", "teaser", "clip"}
+
+	videos := sortVideos(res, "de")
+	for n, v := range videos {
+		if v.Key != want[n] {
+			t.Errorf("video %d %q, want %q", n, v.Key, want[n])
+		}
+	}
+
+	if res[0].Key != "clip" {
+		t.Error("sortVideos changed results")
+	}
+
+	if video := getVideo(res, "en"); video != "en" {
+		t.Errorf("video %q, want en", video)
+	}
+}
+
+func TestFullRange(t *testing.T) {
+	if !fullRange("bytes 0-999/1000") {
+		t.Error("bytes 0-999/1000 is full range")
+	}
+
+	if fullRange("bytes 0-499/1000") || fullRange("bytes 500-999/1000") || fullRange("") {
+		t.Error("partial range is full range")
+	}
+}